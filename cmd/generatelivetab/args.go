@@ -12,15 +12,43 @@ import (
 	. "github.com/hwheinzen/stringl10n/mistake"
 )
 
-// args reads flags and arguments and returns the JSON file name.
+// options are the values of the flags and arguments.
+type options struct {
+	jsonFile     string // -json: JSON file, directory or glob pattern
+	lang         string // -lang
+	tabName      string // -from-db
+	openString   string // -db
+	export       bool   // -export
+	tests        bool   // -tests
+	http         bool   // -http
+	openapi      bool   // -openapi
+	ts           bool   // -ts
+	ddl          string // -ddl: SQL dialect
+	check        bool   // -check
+	reproducible bool   // -reproducible
+}
+
+// args reads flags and arguments and returns them as options:
+// the JSON file name (or directory or glob pattern) and, with -from-db,
+// table and database to be described.
 //
-// -json		<name of JSON file> (MUST)
+// -json		<name of JSON file, directory or glob pattern> (MUST, except with -from-db)
+// -from-db		<name of livedb table>
+// -db			<database open string> (MUST with -from-db)
+// -export		exported types and functions (same as Export in JSON file)
+// -tests		generate tests (same as Tests in JSON file)
+// -http		generate HTTP handlers (same as HTTP in JSON file)
+// -openapi		generate OpenAPI documents (same as OpenAPI in JSON file)
+// -ts			generate TypeScript types (same as TypeScript in JSON file)
+// -ddl			<sqlite|postgresql|mysql> generate SQL files (same as DDL in JSON file)
+// -reproducible	omit the timestamp of generation
+// -check		report files which are missing or out of date instead of writing them
 // -lang			<default: en>
 // -version
 //  -help
 //
 // Flag -help only prints a usage description.
-func args(buildtime string) (jsonFile, lang string) {
+func args(buildtime string) (opts options) {
 //	fnc := "args"
 
 	var version bool
@@ -29,9 +57,29 @@ func args(buildtime string) (jsonFile, lang string) {
 	var help bool
 	flag.BoolVar(&help, "help", false, "usage")
 
-	flag.StringVar(&jsonFile, "json", "", "file name (MUST)")
+	flag.StringVar(&opts.jsonFile, "json", "", "file name, directory or glob pattern (MUST, except with -from-db)")
+
+	flag.StringVar(&opts.tabName, "from-db", "", "write JSON file describing this livedb table (stdout without -json)")
+
+	flag.StringVar(&opts.openString, "db", "", "database open string (MUST with -from-db)")
+
+	flag.BoolVar(&opts.export, "export", false, "generate exported types and functions")
+
+	flag.BoolVar(&opts.tests, "tests", false, "generate tests (<file>_test.go)")
+
+	flag.BoolVar(&opts.http, "http", false, "generate HTTP handlers (<file>_http.go)")
+
+	flag.BoolVar(&opts.openapi, "openapi", false, "generate OpenAPI documents (<file>_openapi.json)")
+
+	flag.BoolVar(&opts.ts, "ts", false, "generate TypeScript types (<file>.ts)")
+
+	flag.BoolVar(&opts.reproducible, "reproducible", false, "omit the timestamp of generation")
 
-	flag.StringVar(&lang, "lang", "en", "language of error messages")
+	flag.BoolVar(&opts.check, "check", false, "exit with status 1 if generated files are missing or out of date (implies -reproducible)")
+
+	flag.StringVar(&opts.ddl, "ddl", "", "generate SQL files creating the tables in dialect sqlite, postgresql or mysql (<file>_<dialect>.sql)")
+
+	flag.StringVar(&opts.lang, "lang", "en", "language of error messages")
 
 	flag.Parse()
 
@@ -48,7 +96,7 @@ func args(buildtime string) (jsonFile, lang string) {
 					{"Name", pgm},
 				},
 			}
-			fmt.Println(translate(inf, lang))
+			fmt.Println(translate(inf, opts.lang))
 		} else {
 			inf := Err{
 				Fix: "GENERATELIVETAB:{{.Name}}:version of {{.Nam2}}",
@@ -57,12 +105,28 @@ func args(buildtime string) (jsonFile, lang string) {
 					{"Nam2", buildtime},
 				},
 			}
-			fmt.Println(translate(inf, lang))
+			fmt.Println(translate(inf, opts.lang))
 		}
 		os.Exit(0)
 	}
 
-	if jsonFile == "" {
+	if opts.tabName != "" {
+		if opts.openString == "" {
+			err := Err{
+				Fix: "GENERATELIVETAB:{{.Name}}:{{.Nam2}} argument missing",
+				Var: []struct {Name  string; Value interface{}}{
+					{"Name", pgm},
+					{"Nam2", "-db"},
+				},
+			}
+			fmt.Fprintln(os.Stderr, translate(err, opts.lang))
+			flag.Usage()
+			os.Exit(2)
+		}
+		return opts
+	}
+
+	if opts.jsonFile == "" {
 		err := Err{
 			Fix: "GENERATELIVETAB:{{.Name}}:{{.Nam2}} argument missing",
 			Var: []struct {Name  string; Value interface{}}{
@@ -70,10 +134,10 @@ func args(buildtime string) (jsonFile, lang string) {
 				{"Nam2", "-json"},
 			},
 		}
-		fmt.Fprintln(os.Stderr, translate(err, lang))
+		fmt.Fprintln(os.Stderr, translate(err, opts.lang))
 		flag.Usage()
 		os.Exit(2)
 	}
 
-	return jsonFile, lang
+	return opts
 }
@@ -0,0 +1,96 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/hwheinzen/livedb"
+)
+
+// metaFile is the name pattern of the SQL files creating
+// the livedb metadata tables.
+const metaFile = "livedb_meta_%s.sql"
+
+// ddlValues are the values of an SQL file (see ddlTmpl).
+type ddlValues struct {
+	File      string
+	Copyright string
+	Generator string
+	Generated string
+	Input     string
+	Dialect   string
+	Desc      string // what the statements create
+	Stmts     []string
+}
+
+// isDialect reports whether name is an SQL dialect known by livedb.
+func isDialect(name string) bool {
+	for _, d := range livedb.Dialects {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
+// makeDDL returns the contents of DDLFile: the statements creating the table of vals
+// in dialect DDL as livedb.Table.Create does.
+func makeDDL(vals *Values) ([]byte, error) {
+	fnc := "makeDDL"
+
+	t := livedb.Table{Name: vals.DbName}
+	for _, att := range vals.Atts {
+		t.Defs = append(t.Defs, att.DbName+" "+att.CreateClause) // as in tmpl
+	}
+	stmts, err := t.DDL(vals.DDL)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	code, err := makeCode("ddlTmpl", ddlTmpl, ddlValues{
+		File:      vals.DDLFile,
+		Copyright: vals.Copyright,
+		Generator: vals.Generator,
+		Generated: vals.Generated,
+		Input:     vals.Input,
+		Dialect:   vals.DDL,
+		Desc:      "the livedb table " + vals.DbName + " with ID-table and indexes",
+		Stmts:     stmts,
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return code, nil
+}
+
+// makeMetaDDL returns the name and contents of the SQL file
+// with the statements creating the livedb metadata tables in dialect.
+func makeMetaDDL(all *allValues, dialect string) (string, []byte, error) {
+	fnc := "makeMetaDDL"
+
+	stmts, err := livedb.MetaDDL(dialect)
+	if err != nil {
+		return "", nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	file := fmt.Sprintf(metaFile, dialect)
+	code, err := makeCode("ddlTmpl", ddlTmpl, ddlValues{
+		File:      file,
+		Copyright: all.Copyright,
+		Generator: all.Generator,
+		Generated: all.Generated,
+		Input:     all.Input,
+		Dialect:   dialect,
+		Desc:      "the livedb metadata tables (registry and migrations)",
+		Stmts:     stmts,
+	}, false)
+	if err != nil {
+		return "", nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return file, code, nil
+}
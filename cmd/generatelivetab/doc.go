@@ -6,6 +6,31 @@
 //
 // It uses descriptive data from a JSON file.
 //
+// Flag -json may name a directory (all *.json files in it) or a glob pattern
+// instead, generated OpenAPI documents (*_openapi.json) excluded,
+// and a JSON file may describe several tables (see Tables).
+// Then generatelivetab generates a file for each table and
+// tables_generated.go with the table registry 'livedbTables'
+// and function 'createAllTables' creating all tables in order.
+// All tables must share Package, ErrorType and Export,
+// and their Acronyms, Names, DbNames, Files and Prefixes must be unique.
+//
+// With -from-db=<table> -db=<open string> it writes such a JSON file
+// (to stdout without -json) for an existing livedb table instead:
+// attribute definitions are taken from the livedb registry (livedb_tables)
+// or inferred from the database catalog; Copyright and Package have to be
+// completed. Build generatelivetab with the tags of the database used
+// (see livedb).
+//
+// With -reproducible the generated files contain no timestamp, so that
+// unchanged JSON files and generator versions reproduce them exactly.
+// With -check (which implies -reproducible) generatelivetab writes nothing;
+// it reports generated files which are missing or differ from what it would
+// generate and exits with status 1, e.g. to verify generated code in CI:
+//	generatelivetab -json=mi.json -reproducible   # go:generate
+//	generatelivetab -json=mi.json -check          # CI
+// Use the same flags in both runs.
+//
 // The JSON file must contain:
 // ---------------------------
 //	Copyright    - year and copyright owner
@@ -20,20 +45,141 @@
 // --------------------------
 //	File         - output filename, default is <Acronym>_generated.go
 //	DbName       - database table name - if Name contains non-ASCII characters
+//	Version      - schema version > 0 -> function 'migrate<Acronym>' will be generated;
+//	               increase it after changing Atts
+//	Export       - true -> exported types and functions (e.g. 'StartMi', 'Mitarbeiter')
+//	               for use in other packages; flag -export does the same
+//	JSONCase     - JSON names of fields: camel (default, e.g. 'usID'),
+//	               snake (e.g. 'us_id') or none (no JSON tags);
+//	               with snake the record type encodes the fields of livedb.Std
+//	               in snake case too (e.g. 'created_by'), with none livedb's names
+//	Tests        - true -> <File>_test.go will be generated (see below);
+//	               flag -tests does the same
+//	HTTP         - true -> <File>_http.go with '<acronym>Handler' will be
+//	               generated (see below); flag -http does the same
+//	OpenAPI      - true -> <File without .go>_openapi.json describing the API of
+//	               the handler will be generated (see below); flag -openapi does the same
+//	TypeScript   - true -> <File without .go>.ts with TypeScript interfaces of
+//	               the JSON types will be generated (see below); flag -ts does the same
+//	DDL          - sqlite, postgresql or mysql -> <File without .go>_<DDL>.sql
+//	               creating the table will be generated (see below);
+//	               flag -ddl=<dialect> does the same
+//	Prefix       - path prefix of the handler used by OpenAPI and tests,
+//	               default is /<lower case Name>
+//
+// A JSON file describing several tables contains Tables instead of Name,
+// Acronym and Atts:
+// ---------------------------------------------------------------------
+//	Tables       - list of tables as described above; Copyright, Package,
+//	               ErrorType, JSONCase, Export, Tests, HTTP, OpenAPI, TypeScript
+//	               and DDL of the file apply to all of them
+//	File         - output filename of cross-table code,
+//	               default is tables_generated.go
 //
 // Attributes must contain:
 // ------------------------
 //	Name         - attribute/field name
-//	CreateClause - attributes create clause
+//	CreateClause - attributes create clause (without ; -- or /*)
 //
 // Attributes may contain:
 // -----------------------
-//	IsNumType    - true -> int
+//	Kind         - string (default), int, bool, float, decimal, date, timestamp or bytes
+//	Nullable     - true -> pointer field, nil is stored as NULL
+//	IsNumType    - true -> int (same as Kind int)
 //	DbName       - database field name - if Name contains non-ASCII characters
 //	ReadBy       - true -> function 'by<Name>Ts' will be generated
+//	JSONName     - name (and options) in JSON, e.g. "title,omitempty",
+//	               instead of Name in JSONCase
+//	Tags         - further struct tags, e.g. db:"name" validate:"required";
+//	               a json tag replaces the JSON name
+//	Required     - true -> value must not be empty (zero, nil); not for bool
+//	MaxLen       - maximum length in characters of string attributes,
+//	               default is n of varchar(n) or char(n) in CreateClause
+//	Min, Max     - limits of int and float attributes
+//	Pattern      - regular expression string attributes must match
+//	Enum         - list of allowed values of string attributes
+//	Samples      - two different Go expressions of the field type (without
+//	               pointer) used by generated tests, e.g. ["\"Abc\"", "\"Xyz\""];
+//	               needed with Pattern, defaults depend on Kind and rules
+//
+// Database names (default: "t"+lower case Name, lower case Name)
+// must be lower case ASCII letters, digits and underscores.
+//
+// Kinds and Go field types:
+//	string    - string
+//	int       - int
+//	bool      - bool
+//	float     - float64
+//	decimal   - string    (exact decimal, e.g. "12.34")
+//	date      - time.Time (calendar day of its zone, read as midnight UTC)
+//	timestamp - time.Time (stored in UTC, read in UTC)
+//	bytes     - []byte    (always nullable, nil is stored as NULL)
+// The CreateClause must declare a matching database type.
+// Fields of attributes that are not Nullable store zero values as they are,
+// NULL is read as zero value.
+//
+// Function 'validate<Acronym>', called by 'start<Acronym>' and 'change<Acronym>',
+// checks the validation rules and reports all violations in one error of type
+// '<acronym>Fails', a list of Err with the attribute name in Name:
+//	"<PACKAGE>:{{.Name}} is required"
+//	"<PACKAGE>:{{.Name}} is longer than {{.Int}} characters"
+//	"<PACKAGE>:{{.Name}} is less than {{.Nam2}}"
+//	"<PACKAGE>:{{.Name}} is greater than {{.Nam2}}"
+//	"<PACKAGE>:{{.Name}} does not match {{.Nam2}}"
+//	"<PACKAGE>:{{.Name}} is not one of {{.Nam2}}"  (Nam2 e.g. "a|b|c")
+// Translate each Err of the list (errors.As) with the l10n file of the package.
+// Pattern and Enum are not checked on empty strings; nil values violate
+// Required only.
+//
+// The generated test 'Test<Acronym>Generated' runs with Sqlite (build tags
+// !mysql,!postgresql) in a transaction that is rolled back; it opens a temporary
+// database unless livedb.GDb is open already. It creates the table and uses
+// the sample values to exercise empty, start, change, moveBegin, moveUntil,
+// terminate and all read functions including those of ReadBy.
+//
+// The generated '<acronym>Handler' (an http.Handler) serves the table
+// as REST/JSON resource below its Prefix:
+//	GET    Prefix?ts=           all records valid at ts (default livedb.Now)
+//	GET    Prefix/{id}?ts=      record of ID valid at ts
+//	GET    Prefix/{id}/history  all records of ID
+//	POST   Prefix?ts=           'start<Acronym>' with the attributes of the body
+//	PUT    Prefix/{id}?ts=      'change<Acronym>' with body {"old": ..., "new": ...};
+//	                            an outdated old record is a conflict
+//	DELETE Prefix/{id}?ts=      'terminate<Acronym>' of the record valid at ts
+// Errors are answered with the status code of livedb.HTTPStatus and
+// a JSON body {"code": ..., "error": ...}: code is one of "invalid", "not_found",
+// "conflict" and "internal", error is the translated message (Translate,
+// default English) without call chain; internal errors are not disclosed.
+// The handler logs errors with their call chain (Log, default log.Println).
+// Unknown IDs and bad requests are "<PACKAGE>:{{.Name}} not found"
+// (livedb.ErrNotFound) and "<PACKAGE>:{{.Name}} invalid:{{.Nam2}}"
+// (livedb.ErrInvalid, also of 'validate<Acronym>').
+// With Tests 'Test<Acronym>Handler' tests the handler using httptest.
+//
+// The generated OpenAPI 3 document describes these operations (operationIds
+// read<Acronym>s, start<Acronym>, read<Acronym>, change<Acronym>,
+// terminate<Acronym> and history<Acronym>) below Prefix, and the schemas
+// Std (livedb.Std), <Acronym> (attributes with JSON names, nullability and
+// validation rules), <Name> (both), <Acronym>Pair and Error, so that clients
+// may be generated from it. Prefix must match the Prefix of the handler.
+//
+// The generated SQL file contains the statements of livedb.Table.DDL creating
+// the table, its ID-table and indexes exactly as livedb.Table.Create would
+// (livedb uses no further constraints or triggers); livedb_meta_<DDL>.sql
+// those of livedb.MetaDDL creating the metadata tables livedb_tables and
+// livedb_migrations. Applications then only register the tables
+// with 'create<Acronym>'. Migrations ('migrate<Acronym>') are not covered.
+//
+// The generated TypeScript file declares the interfaces Std, <Acronym>,
+// <Name> (extends both) and <Acronym>Pair matching the JSON encoding:
+// properties omitted if empty are optional, Nullable attributes may be null,
+// Enum gives a union of string literals; decimals, dates, timestamps and
+// bytes (base64) are strings.
 //
-// (Livedb tables only use the types int and string;
-//  date, time, and timestamp are stored as strings.)
+// JSON names of Nullable attributes are omitted if empty. The standard
+// attributes (livedb.Std) have camel case JSON names, Old and New of
+// <Acronym>Pair are "old" and "new", so the generated types may serve
+// as API types.
 //
 // Example:
 /*
@@ -0,0 +1,307 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hwheinzen/livedb"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// description is the layout of the JSON file read by getValues.
+type description struct {
+	Copyright string
+	Package   string
+	ErrorType string
+	Name      string
+	Acronym   string
+	DbName    string
+	Version   int `json:",omitempty"`
+	Atts      []attDescription
+}
+
+type attDescription struct {
+	Name         string
+	CreateClause string
+	Kind         string `json:",omitempty"`
+	Nullable     bool   `json:",omitempty"`
+	IsNumType    bool   `json:",omitempty"`
+	DbName       string `json:",omitempty"`
+}
+
+// fromDB describes the existing livedb table tabName of database openString
+// and writes the JSON file jsonFile (stdout if empty).
+//
+// Attribute definitions are taken from the livedb registry if the table
+// is registered, otherwise they are inferred from the database catalog.
+func fromDB(tabName, openString, jsonFile string) error {
+	fnc := "fromDB"
+
+	err := livedb.Open(openString)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	defer livedb.Close()
+
+	t := livedb.Table{Name: tabName}
+	ok, err := t.Exists(nil)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	if !ok {
+		err := Err{
+			Fix: "GENERATELIVETAB:table {{.Name}} not found",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", tabName},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	desc, err := describe(&t)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	out, err := json.MarshalIndent(desc, "", "\t")
+	if err != nil {
+		e := Err{
+			Fix: "GENERATELIVETAB:encode JSON for {{.Name}} failed",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", tabName},
+			},
+		}
+		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+	out = append(out, '\n')
+
+	if jsonFile == "" {
+		_, err = os.Stdout.Write(out)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+		return nil
+	}
+
+	err = os.WriteFile(jsonFile, out, 0644)
+	if err != nil {
+		e := Err{
+			Fix: "GENERATELIVETAB:create file {{.Name}} failed",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", jsonFile},
+			},
+		}
+		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	_, err = getValues(jsonFile) // valid?
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+// describe returns the description of livedb table t.
+func describe(t *livedb.Table) (description, error) {
+	fnc := "describe"
+
+	desc := description{
+		Copyright: strconv.Itoa(time.Now().Year()), // to be completed
+		Package:   "main",                          // to be adjusted
+		ErrorType: "Err",
+		DbName:    t.Name,
+	}
+
+	defs, origin, err := registeredDefs(t, &desc)
+	if err != nil {
+		return desc, fmt.Errorf(fnc+":%w", err)
+	}
+
+	desc.Name = tableName(t.Name, strings.HasPrefix(origin, pgm))
+	desc.Acronym = desc.Name[:2]
+	if len(desc.Name) == 2 { // Acronym and Name name Go types
+		desc.Acronym += "_"
+	}
+
+	if defs == nil {
+		defs, err = catalogDefs(t)
+		if err != nil {
+			return desc, fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	for _, def := range defs {
+		fields := strings.Fields(def)
+		if len(fields) < 2 { // no declared type, e.g. Sqlite "alter table x add column b"
+			fields = append(fields, "text")
+		}
+		att := attDescription{
+			Name:         goName(fields[0]),
+			CreateClause: strings.Join(fields[1:], " "),
+		}
+		switch k := kindOf(fields[1]); k {
+		case "string":
+		case "int":
+			att.IsNumType = true
+		default:
+			att.Kind = k
+		}
+		clause := strings.ToLower(att.CreateClause)
+		att.Nullable = att.Kind != "bytes" &&
+			!strings.Contains(clause, "not null") && !strings.Contains(clause, "primary key")
+		if strings.ToLower(att.Name) != fields[0] {
+			att.DbName = fields[0]
+		}
+		desc.Atts = append(desc.Atts, att)
+	}
+
+	return desc, nil
+}
+
+// tableName returns the Go name of table dbName.
+// Tables of generatelivetab are named "t"+lower case Name by default,
+// their "t" is stripped if the rest fits: "tmitarbeiter" -> "Mitarbeiter".
+// Other tables keep their name: "tasks" -> "Tasks".
+func tableName(dbName string, generated bool) string {
+	name := goName(dbName)
+	if generated {
+		rest := goName(strings.TrimPrefix(dbName, "t"))
+		if "t"+strings.ToLower(rest) == dbName {
+			name = rest
+		}
+	}
+	if len(name) < 2 {
+		name += "_"
+	}
+	return name
+}
+
+// registeredDefs returns the attribute definitions of t
+// as registered in livedb_tables, nil if t is not registered,
+// and the origin registered with t.
+// It completes desc with the registered version.
+func registeredDefs(t *livedb.Table, desc *description) ([]string, string, error) {
+	fnc := "registeredDefs"
+
+	infos, err := livedb.Tables(nil)
+	if err != nil {
+		return nil, "", fmt.Errorf(fnc+":%w", err)
+	}
+	for _, info := range infos {
+		if info.Name == t.Name {
+			desc.Version = info.Version
+			if info.Defs == nil {
+				return []string{}, info.Origin, nil // empty table
+			}
+			return info.Defs, info.Origin, nil
+		}
+	}
+
+	return nil, "", nil
+}
+
+// catalogDefs infers the attribute definitions of t
+// from the columns beyond livedb.StdAtts.
+func catalogDefs(t *livedb.Table) ([]string, error) {
+	fnc := "catalogDefs"
+
+	cols, err := t.Columns(nil)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	var defs []string
+	for _, col := range cols {
+		std := false
+		for _, att := range livedb.StdAtts {
+			if col.Name == att {
+				std = true
+				break
+			}
+		}
+		if std {
+			continue
+		}
+
+		typ := col.Type
+		switch typ { // Postgres reports standard names
+		case "character varying":
+			typ = "varchar"
+		case "character":
+			typ = "char"
+		case "timestamp without time zone":
+			typ = "timestamp"
+		}
+		def := col.Name + " " + typ
+		if col.Length > 0 {
+			def += "(" + strconv.Itoa(col.Length) + ")"
+		}
+		if !col.Nullable {
+			def += " not null"
+		}
+		defs = append(defs, def)
+	}
+
+	return defs, nil
+}
+
+// goName returns database name name as exported Go name:
+// "us_id" -> "UsId".
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	if b.Len() == 0 || b.String()[0] >= '0' && b.String()[0] <= '9' {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+// kindOf returns the Kind of attributes of database type typ.
+func kindOf(typ string) string {
+	typ = strings.ToLower(typ)
+	if i := strings.Index(typ, "("); i >= 0 {
+		typ = typ[:i]
+	}
+	switch typ {
+	case "integer", "int", "smallint", "bigint", "tinyint", "mediumint",
+		"int2", "int4", "int8", "serial", "bigserial":
+		return "int"
+	case "boolean", "bool":
+		return "bool"
+	case "real", "float", "float4", "float8", "double":
+		return "float"
+	case "decimal", "numeric":
+		return "decimal"
+	case "date":
+		return "date"
+	case "timestamp", "datetime":
+		return "timestamp"
+	case "bytea", "blob", "binary", "varbinary":
+		return "bytes"
+	}
+	return "string"
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for describing existing tables.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hwheinzen/livedb"
+)
+
+// TestDescribe tests describing existing livedb tables
+// and reading the descriptions as JSON input.
+func TestDescribe(t *testing.T) {
+
+	dir := t.TempDir()
+	err := livedb.Open(filepath.Join(dir, "testdb"))
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer livedb.Close()
+
+	type describeTest struct {
+		table  string
+		defs   []string
+		origin string
+		alter  []string // statements run after Create
+		//
+		name string // expected Name
+		atts int    // expected number of attributes
+	}
+	describeTests := []describeTest{
+		{"tmitarbeiter", []string{"name varchar(50) not null"}, pgm + " 2021", nil, "Mitarbeiter", 1},
+		{"tasks", []string{"title varchar(50) not null", "done boolean"}, "", nil, "Tasks", 2},
+		{"tkonto", []string{"nr integer not null"}, "", nil, "Tkonto", 1},
+		{"tmi", []string{"nr integer not null"}, pgm + " 2021", nil, "Mi", 1}, // Acronym differs
+		{"tnotes", []string{"text varchar(200)"}, "", []string{
+			"alter table tnotes add column b;",                  // no declared type
+			"delete from livedb_tables where tabname='tnotes';", // catalog only
+		}, "Tnotes", 2},
+	}
+
+	for i, v := range describeTests {
+		tab := livedb.Table{Name: v.table, Defs: v.defs, Origin: v.origin}
+		err := tab.Create(nil)
+		for _, s := range v.alter {
+			if err == nil {
+				_, err = livedb.GDb.Exec(s)
+			}
+		}
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal("#"+fmt.Sprintf("%d", i+1), err)
+		}
+
+		desc, err := describe(&livedb.Table{Name: v.table}) // <------- ACTION
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+			continue
+		}
+
+		out, err := json.Marshal(desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jsonFile := filepath.Join(dir, v.table+".json")
+		err = os.WriteFile(jsonFile, out, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vals, err := getValues(jsonFile) // <------- ACTION (round trip)
+		switch {
+		case err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case vals.Name != v.name:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected Name", v.name, "got", vals.Name)
+		case vals.DbName != v.table:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected DbName", v.table, "got", vals.DbName)
+		case len(vals.Atts) != v.atts:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.atts, "attributes, got", len(vals.Atts))
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/scanner"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// genFile is a file generated in memory.
+type genFile struct {
+	name string
+	code []byte
+}
+
+// generate returns all files to be generated for all.
+func generate(all *allValues) ([]genFile, error) {
+	fnc := "generate"
+
+	var files []genFile
+	add := func(name string, code []byte, err error) error {
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+		files = append(files, genFile{name, code})
+		return nil
+	}
+
+	for i := range all.Tables {
+		vals := &all.Tables[i]
+
+		code, err := makeGoCode("tmpl", tmpl, vals, vals.Export)
+		if err = add(vals.File, code, err); err != nil {
+			return nil, err
+		}
+		if vals.HTTP {
+			code, err := makeGoCode("httpTmpl", httpTmpl, vals, vals.Export)
+			if err = add(vals.HTTPFile, code, err); err != nil {
+				return nil, err
+			}
+		}
+		if vals.OpenAPI {
+			code, err := makeOpenAPI(vals)
+			if err = add(vals.OpenAPIFile, code, err); err != nil {
+				return nil, err
+			}
+		}
+		if vals.DDL != "" {
+			code, err := makeDDL(vals)
+			if err = add(vals.DDLFile, code, err); err != nil {
+				return nil, err
+			}
+		}
+		if vals.TypeScript {
+			code, err := makeCode("tsTmpl", tsTmpl, vals, vals.Export)
+			if err = add(vals.TSFile, code, err); err != nil {
+				return nil, err
+			}
+		}
+		if vals.Tests {
+			code, err := makeGoCode("testTmpl", testTmpl, vals, vals.Export)
+			if err = add(vals.TestFile, code, err); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	dialects := map[string]bool{}
+	for _, vals := range all.Tables {
+		if vals.DDL != "" && !dialects[vals.DDL] {
+			dialects[vals.DDL] = true
+			name, code, err := makeMetaDDL(all, vals.DDL)
+			if err = add(name, code, err); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if all.File != "" { // several tables
+		code, err := makeGoCode("allTmpl", allTmpl, all, all.Export)
+		if err = add(all.File, code, err); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// writeFiles writes files, each atomically: a file has its old
+// or its new contents, never partial ones.
+func writeFiles(files []genFile) error {
+	fnc := "writeFiles"
+
+	for _, f := range files {
+		err := writeFile(f.name, f.code)
+		if err != nil {
+			e := Err{
+				Fix: "GENERATELIVETAB:create file {{.Name}} failed",
+				Var: []struct {Name  string; Value interface{}}{
+					{"Name", f.name},
+				},
+			}
+			return fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+	}
+
+	return nil
+}
+
+// writeFile writes code to a temporary file and renames it to name.
+// It keeps the permissions of an existing file.
+func writeFile(name string, code []byte) (err error) {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(name); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	_, err = tmp.Write(code)
+	if err == nil {
+		err = tmp.Chmod(perm)
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// reproducible removes the timestamps of generation from all.
+func reproducible(all *allValues) {
+	all.Generated = ""
+	for i := range all.Tables {
+		all.Tables[i].Generated = ""
+	}
+}
+
+// check reports files which are missing or differ from their
+// generated code to w and returns the exit status of -check:
+// 0 if there are none, else 1.
+func check(w io.Writer, files []genFile, lang string) int {
+	stale := staleFiles(files)
+	for _, name := range stale {
+		inf := Err{
+			Fix: "GENERATELIVETAB:{{.Name}} is out of date",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", name},
+			},
+		}
+		fmt.Fprintln(w, translate(inf, lang))
+	}
+	if len(stale) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// staleFiles returns the names of files which are missing
+// or differ from their generated code.
+func staleFiles(files []genFile) []string {
+	var stale []string
+	for _, f := range files {
+		code, err := os.ReadFile(f.name)
+		if err != nil || !bytes.Equal(code, f.code) {
+			stale = append(stale, f.name)
+		}
+	}
+	return stale
+}
+
+// makeGoCode returns the Go code of template text (named name)
+// executed with data, formatted by go/format.
+func makeGoCode(name, text string, data interface{}, export bool) ([]byte, error) {
+	fnc := "makeGoCode"
+
+	code, err := makeCode(name, text, data, export)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+	code, err = gofmt(name, code)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return code, nil
+}
+
+// makeCode returns the result of template text (named name) executed with data.
+func makeCode(name, text string, data interface{}, export bool) ([]byte, error) {
+	fnc := "makeCode"
+
+	t := template.New(name).Funcs(template.FuncMap{
+		"exp": func(name string) string { // exported name if wanted
+			if !export || name == "" {
+				return name
+			}
+			return upperFirst(name)
+		},
+	})
+	_, err := t.Parse(text)
+	if err != nil {
+		e := Err{
+			Fix: "GENERATELIVETAB:parse template {{.Name}} failed",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", name},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	var buf bytes.Buffer
+	err = t.Execute(&buf, data)
+	if err != nil {
+		e := Err{
+			Fix: "GENERATELIVETAB:execute template {{.Name}} failed",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", name},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// upperFirst returns s with its first letter in upper case:
+// "ärzte" -> "Ärzte".
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// gofmt returns code formatted like gofmt does. The error of
+// invalid code shows the lines around the first error position.
+func gofmt(name string, code []byte) ([]byte, error) {
+	fnc := "gofmt"
+
+	out, err := format.Source(code)
+	if err != nil {
+		e := Err{
+			Fix: "GENERATELIVETAB:format code of template {{.Name}} failed",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", name},
+			},
+		}
+		var list scanner.ErrorList
+		if errors.As(err, &list) && len(list) > 0 {
+			return nil, fmt.Errorf(fnc+":%w:%s\n%s", e, err, context(code, list[0].Pos.Line)) // code contains %
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return out, nil
+}
+
+// context returns the lines of code around line (numbered, line marked).
+func context(code []byte, line int) string {
+	const around = 3
+
+	lines := strings.Split(string(code), "\n")
+	var buf strings.Builder
+	for i := line - around; i <= line+around; i++ {
+		if i < 1 || i > len(lines) {
+			continue
+		}
+		mark := " "
+		if i == line {
+			mark = ">"
+		}
+		fmt.Fprintf(&buf, "%s%5d: %s\n", mark, i, lines[i-1])
+	}
+	return buf.String()
+}
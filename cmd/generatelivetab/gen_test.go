@@ -0,0 +1,339 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for code generation.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const lang = "en"
+
+// TestUpperFirst tests upper-casing the first letter, also non-ASCII ones.
+func TestUpperFirst(t *testing.T) {
+
+	type upperFirstTest struct {
+		in string
+		//
+		want string
+	}
+	upperFirstTests := []upperFirstTest{
+		{"", ""},
+		{"mi", "Mi"},
+		{"Mi", "Mi"},
+		{"ärzte", "Ärzte"},
+		{"éclair", "Éclair"},
+		{"_x", "_x"},
+		{"\xc3", "\xc3"}, // invalid UTF-8 remains
+	}
+
+	for i, v := range upperFirstTests {
+		got := upperFirst(v.in) // <------- ACTION
+		if got != v.want {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.want, "got", got)
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+}
+
+// TestGenerateExportNonASCII tests exported code of a table
+// whose Name and Acronym begin with a non-ASCII letter.
+func TestGenerateExportNonASCII(t *testing.T) {
+
+	jsonFile := filepath.Join(t.TempDir(), "az.json")
+	err := os.WriteFile(jsonFile, []byte(`{
+	"Copyright": "2021 Itts Mee"
+	,"Package":   "example"
+	,"ErrorType": "Err"
+	,"Name":      "Ärzte"
+	,"Acronym":   "äz"
+	,"DbName":    "taerzte"
+	,"Atts":      [
+		{"Name": "Name", "CreateClause": "varchar(50) not null", "ReadBy": true}
+	]
+}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := getAllValues(options{jsonFile: jsonFile, export: true, tests: true, http: true})
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	files, err := generate(&all) // <------- ACTION (formats Go code)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"type Äz struct",
+		"type Ärzte struct",
+		"func StartÄz(xp *Ärzte,",
+	} {
+		if !bytes.Contains(files[0].code, []byte(want)) {
+			t.Error("expected", files[0].name, "to contain", want)
+		}
+	}
+}
+
+// TestCheck tests the exit status of -check before and after
+// the JSON file changes.
+func TestCheck(t *testing.T) {
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir()) // generated files go to the working directory
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	const (
+		name = `{"Name": "Name", "CreateClause": "varchar(50) not null"}`
+		nr   = `{"Name": "Nr", "CreateClause": "integer", "IsNumType": true, "Min": 1}`
+	)
+
+	type checkTest struct {
+		atts  string // Atts of mi.json
+		write bool   // write generated files before -check
+		//
+		want int // exit status
+	}
+	checkTests := []checkTest{
+		{name, true, 0},
+		{name, false, 0},            // unchanged
+		{name + "," + nr, false, 1}, // JSON edited
+		{name + "," + nr, true, 0},
+	}
+
+	for i, v := range checkTests {
+		err = os.WriteFile("mi.json", []byte(`{
+	"Copyright": "2021 Itts Mee"
+	,"Package":   "example"
+	,"ErrorType": "Err"
+	,"Name":      "Mitarbeiter"
+	,"Acronym":   "Mi"
+	,"Atts":      [`+v.atts+`]
+}`), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		all, err := getAllValues(options{jsonFile: "mi.json", tests: true, check: true})
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal("#"+fmt.Sprintf("%d", i+1), err)
+		}
+		reproducible(&all)
+		files, err := generate(&all)
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal("#"+fmt.Sprintf("%d", i+1), err)
+		}
+		if v.write {
+			err = writeFiles(files)
+			if err != nil {
+				err = translate(err, lang) // ******** l10n ********
+				t.Fatal("#"+fmt.Sprintf("%d", i+1), err)
+			}
+		}
+
+		var out bytes.Buffer
+		status := check(&out, files, lang) // <------- ACTION
+		switch {
+		case status != v.want:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected status", v.want, "got", status, out.String())
+		case status != 0 && !strings.Contains(out.String(), "mi_generated.go is out of date"):
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected mi_generated.go out of date, got", out.String())
+		case status == 0 && out.Len() > 0:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected no report, got", out.String())
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestBrokenTemplate tests that errors of templates show the lines
+// of the unformatted code and that nothing is written.
+func TestBrokenTemplate(t *testing.T) {
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	err = os.Chdir(dir) // generated files go to the working directory
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	type brokenTest struct {
+		text string
+		//
+		want []string // parts of the error
+	}
+	brokenTests := []brokenTest{
+		{"package x\n{{.Name", []string{"error parsing broken", "unclosed action"}},
+		{"package x\n{{.Unknown}}", []string{"error executing template broken", "Unknown"}},
+		{"package x\n\nfunc f() {\n\tx := {{.Name}}(\n}\n", []string{
+			"format code of template broken failed",
+			"    3: func f() {",
+			"    4: \tx := Mi(",
+			">    5: }",
+		}},
+	}
+
+	for i, v := range brokenTests {
+		code, err := makeGoCode("broken", v.text, &Values{Name: "Mi"}, false) // <------- ACTION
+		if err == nil {
+			writeFiles([]genFile{{"broken.go", code}}) // as main does
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+			continue
+		}
+		err = translate(err, lang) // ******** l10n ********
+		for _, want := range v.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Error("#"+fmt.Sprintf("%d", i+1), "expected error with", want, "got", err)
+			}
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+	}
+
+	// a file that cannot be replaced leaves no temporary file
+	err = os.Mkdir("mi_generated.go", 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writeFiles([]genFile{{"mi_generated.go", []byte("package x\n")}})
+	if err == nil {
+		t.Error("expected error writing over a directory, got ok")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Error("expected only directory mi_generated.go, got", names)
+	}
+}
+
+// TestVetGenerated tests generated code of several tables with go vet.
+func TestVetGenerated(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("go vet takes long")
+	}
+	gocmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found:", err)
+	}
+	root, err := filepath.Abs(filepath.Join("..", "..")) // livedb module
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	err = os.Chdir(dir) // generated files go to the working directory
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	files := map[string]string{
+		"go.mod": `module example
+
+go 1.17
+
+require (
+	github.com/hwheinzen/livedb v0.0.0
+	github.com/hwheinzen/stringl10n v1.2.1
+)
+
+replace github.com/hwheinzen/livedb => ` + root + `
+`,
+		"go.sum": string(sum),
+		"mi.json": `{
+	"Copyright": "2021 Itts Mee"
+	,"Package":   "example"
+	,"ErrorType": "Err"
+	,"Name":      "Mitarbeiter"
+	,"Acronym":   "Mi"
+	,"JSONCase":  "snake"
+	,"Atts":      [
+		{"Name": "Name", "CreateClause": "varchar(50) not null", "ReadBy": true, "Required": true,
+			"Pattern": "^[A-Z]", "Samples": ["\"Abc\"", "\"Xyz\""]}
+		,{"Name": "UsID", "CreateClause": "integer", "IsNumType": true, "Nullable": true, "Min": 1}
+		,{"Name": "Rolle", "CreateClause": "varchar(10)", "Enum": ["chef", "team"]}
+	]
+}`,
+		"ko.json": `{
+	"Copyright": "2021 Itts Mee"
+	,"Package":   "example"
+	,"ErrorType": "Err"
+	,"Name":      "Konto"
+	,"Acronym":   "Ko"
+	,"Atts":      [
+		{"Name": "Nr", "CreateClause": "integer not null", "ReadBy": true}
+		,{"Name": "Saldo", "CreateClause": "decimal(12,2)", "Kind": "decimal"}
+		,{"Name": "Tag", "CreateClause": "date", "Kind": "date", "Nullable": true}
+	]
+}`,
+	}
+	for name, content := range files {
+		err = os.WriteFile(name, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := getAllValues(options{jsonFile: ".", tests: true, http: true})
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	gen, err := generate(&all)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	err = writeFiles(gen)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(gocmd, "vet", ".") // <------- ACTION
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatal("go vet:", err, "\n"+string(out))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+)
+
+// kind describes how attributes of a Kind are declared, scanned and stored.
+type kind struct {
+	goType    string // Go field type
+	nullType  string // type scanned into, "" -> scan into field directly
+	nullField string // field of nullType holding the value
+	convert   string // conversion of the nullField value
+	value     string // value handed to the database; %s is the field
+}
+
+// kinds maps the Kinds of attributes.
+//
+// Timestamps are stored in UTC. Dates are stored with the calendar day
+// of their own zone (converting to UTC could change the day)
+// and read back at midnight UTC.
+var kinds = map[string]kind{
+	"string":    {"string", "sql.NullString", "String", "", "%s"},
+	"int":       {"int", "sql.NullInt64", "Int64", "int", "%s"},
+	"bool":      {"bool", "sql.NullBool", "Bool", "", "%s"},
+	"float":     {"float64", "sql.NullFloat64", "Float64", "", "%s"},
+	"decimal":   {"string", "sql.NullString", "String", "", "%s"}, // exact, e.g. "12.34"
+	"date":      {"time.Time", "livedb.NullTime", "Time", "", "%s.Format(livedb.DateLayout)"},
+	"timestamp": {"time.Time", "livedb.NullTime", "Time", "", "%s.UTC().Format(livedb.TmspLayout)"},
+	"bytes":     {"[]byte", "", "", "", "%s"}, // nil is NULL
+}
+
+// setKind computes the values depending on the Kind of att.
+// It reports false if Kind is unknown or contradicts IsNumType.
+//
+// Nullable attributes are pointers (except bytes), nil is NULL.
+// Other attributes store zero values, NULL is read as zero value.
+func setKind(att *Att) bool {
+	switch {
+	case att.Kind == "" && att.IsNumType:
+		att.Kind = "int"
+	case att.Kind == "":
+		att.Kind = "string"
+	case att.IsNumType && att.Kind != "int":
+		return false
+	}
+	k, ok := kinds[att.Kind]
+	if !ok {
+		return false
+	}
+	att.IsNumType = att.Kind == "int"
+
+	att.GoType = k.goType
+	att.NullType = k.nullType
+	att.NullField = k.nullField
+	att.Convert = k.convert
+	value := k.value
+	if att.Type != "" && (att.Kind == "string" || att.Kind == "int") { // named type
+		att.GoType = att.Type
+		att.Convert = att.Type
+		value = "fmt.Sprint(%s)"
+	}
+	att.ArgType = att.GoType
+	att.ArgExpr = fmt.Sprintf(value, att.LcName)
+
+	field := "x." + att.Name
+	switch {
+	case att.Kind == "bytes":
+		att.Nullable = true
+		att.ValCond = field + " != nil"
+	case att.Nullable:
+		att.GoType = "*" + att.GoType
+		att.ValCond = field + " != nil"
+		if value == "%s" {
+			field = "*" + field
+		} else {
+			field = "(*" + field + ")"
+		}
+	default:
+		att.ValCond = ""
+	}
+	att.ValExpr = fmt.Sprintf(value, field)
+
+	return true
+}
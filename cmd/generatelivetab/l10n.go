@@ -8,6 +8,8 @@ package main
 
 import (
 	"fmt"
+
+	"github.com/hwheinzen/livedb"
 )
 
 func translate(in error, lang string) (out error) {
@@ -24,6 +26,13 @@ func translate(in error, lang string) (out error) {
 	if out != nil {
 		return out
 	}
+	out, err = livedb.L10nLocalizeError(in, lang) // e.g. with -from-db
+	if err != nil {
+		return err
+	}
+	if out != nil {
+		return out
+	}
 	// else: NOTFOUND
 
 	return fmt.Errorf(fnc+":%w", in)
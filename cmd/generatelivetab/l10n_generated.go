@@ -1,9 +1,9 @@
-// Copyright 2020 Hans-Werner Heinzen. All rights reserved.
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
 // Use of this source code is governed by a license
 // that can be found in the LICENSE file.
 
 // THIS FILE HAS BEEN GENERATED BY l10n using l10n.json.
-// ON 2021-07-19 15:55:52.236601546 +0200 CEST. DO NOT EDIT.
+// ON 2026-10-18 22:35:16.493280567 +0000 UTC . DO NOT EDIT.
 // CHANGES WILL DISAPPEAR AFTER NEXT RUN OF l10n.
 
 /*
@@ -78,6 +78,7 @@ func L10nTranslate(key, lang string) (string, error) {
 type l10nVars struct {
 	Name string
 	Nam2 string
+	Nam3 string
 	Type string
 }
 
@@ -120,6 +121,13 @@ func L10nReplace(tmpl string, vars []struct {
 				return "", fmt.Errorf(fnc+":%w:", err)
 			}
 			allVars.Nam2 = v
+		case "Nam3":
+			v, ok := pair.Value.(string)
+			if !ok {
+				err = errors.New("L10N:variable 'Nam3' should have type string but has " + fmt.Sprintf("%T", pair.Value) + " in template:\n'" + tmpl + "'\n")
+				return "", fmt.Errorf(fnc+":%w:", err)
+			}
+			allVars.Nam3 = v
 		case "Type":
 			v, ok := pair.Value.(string)
 			if !ok {
@@ -236,6 +244,16 @@ func init() {
    "Value": "JSON-Dekodierung von {{.Name}} fehlgeschlagen"
   }
  ],
+ "GENERATELIVETAB:encode JSON for {{.Name}} failed": [
+  {
+   "Lang": "en",
+   "Value": "encode JSON for {{.Name}} failed"
+  },
+  {
+   "Lang": "de",
+   "Value": "JSON-Kodierung für {{.Name}} fehlgeschlagen"
+  }
+ ],
  "GENERATELIVETAB:execute template {{.Name}} failed": [
   {
    "Lang": "en",
@@ -246,6 +264,26 @@ func init() {
    "Value": "execute Template {{.Name}} fehlgeschlagen"
   }
  ],
+ "GENERATELIVETAB:format code of template {{.Name}} failed": [
+  {
+   "Lang": "en",
+   "Value": "format code of template {{.Name}} failed"
+  },
+  {
+   "Lang": "de",
+   "Value": "Formatieren des Codes von Template {{.Name}} fehlgeschlagen"
+  }
+ ],
+ "GENERATELIVETAB:no JSON file matches {{.Name}}": [
+  {
+   "Lang": "en",
+   "Value": "no JSON file matches {{.Name}}"
+  },
+  {
+   "Lang": "de",
+   "Value": "keine JSON-Datei passt zu {{.Name}}"
+  }
+ ],
  "GENERATELIVETAB:open {{.Name}} failed": [
   {
    "Lang": "en",
@@ -266,6 +304,26 @@ func init() {
    "Value": "parse Template {{.Name}} fehlgeschlagen"
   }
  ],
+ "GENERATELIVETAB:table {{.Name}} not found": [
+  {
+   "Lang": "en",
+   "Value": "table {{.Name}} not found"
+  },
+  {
+   "Lang": "de",
+   "Value": "Tabelle {{.Name}} nicht gefunden"
+  }
+ ],
+ "GENERATELIVETAB:{{.Nam2}} in {{.Name}} differs from {{.Nam3}}": [
+  {
+   "Lang": "en",
+   "Value": "{{.Nam2}} in {{.Name}} differs from {{.Nam3}}"
+  },
+  {
+   "Lang": "de",
+   "Value": "{{.Nam2}} in {{.Name}} weicht von {{.Nam3}} ab"
+  }
+ ],
  "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is missing": [
   {
    "Lang": "en",
@@ -276,6 +334,66 @@ func init() {
    "Value": "{{.Nam2}} fehlt in {{.Name}}"
   }
  ],
+ "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid identifier": [
+  {
+   "Lang": "en",
+   "Value": "{{.Nam2}} in {{.Name}} is no valid identifier"
+  },
+  {
+   "Lang": "de",
+   "Value": "{{.Nam2}} in {{.Name}} ist kein gültiger Bezeichner"
+  }
+ ],
+ "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid kind": [
+  {
+   "Lang": "en",
+   "Value": "{{.Nam2}} in {{.Name}} is no valid kind"
+  },
+  {
+   "Lang": "de",
+   "Value": "{{.Nam2}} in {{.Name}} ist keine gültige Art"
+  }
+ ],
+ "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid value": [
+  {
+   "Lang": "en",
+   "Value": "{{.Nam2}} in {{.Name}} is no valid value"
+  },
+  {
+   "Lang": "de",
+   "Value": "{{.Nam2}} in {{.Name}} ist kein gültiger Wert"
+  }
+ ],
+ "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is used in {{.Nam3}} already": [
+  {
+   "Lang": "en",
+   "Value": "{{.Nam2}} in {{.Name}} is used in {{.Nam3}} already"
+  },
+  {
+   "Lang": "de",
+   "Value": "{{.Nam2}} in {{.Name}} wird bereits in {{.Nam3}} verwendet"
+  }
+ ],
+ "GENERATELIVETAB:{{.Nam2}} in {{.Name}} must not be negative": [
+  {
+   "Lang": "en",
+   "Value": "{{.Nam2}} in {{.Name}} must not be negative"
+  },
+  {
+   "Lang": "de",
+   "Value": "{{.Nam2}} in {{.Name}} darf nicht negativ sein"
+  }
+ ],
+ "GENERATELIVETAB:{{.Nam2}} in {{.Name}} must not contain ; -- or /*": [
+  {
+   "Lang": "en",
+   "Value": "{{.Nam2}} in {{.Name}} must not contain ; -- or /*"
+  },
+  {
+   "Lang": "de",
+   "Value": "{{.Nam2}} in {{.Name}} darf weder ; noch -- noch /* enthalten"
+  }
+ ],
  "GENERATELIVETAB:{{.Nam2}} in {{.Name}} too short": [
   {
    "Lang": "en",
@@ -286,6 +404,16 @@ func init() {
    "Value": "{{.Nam2}} in {{.Name}} zu kurz"
   }
  ],
+ "GENERATELIVETAB:{{.Name}} is out of date": [
+  {
+   "Lang": "en",
+   "Value": "{{.Name}} is out of date"
+  },
+  {
+   "Lang": "de",
+   "Value": "{{.Name}} ist veraltet"
+  }
+ ],
  "GENERATELIVETAB:{{.Name}}:unknown version": [
   {
    "Lang": "en",
@@ -9,12 +9,11 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/dullgiulio/jsoncomments"
+	"github.com/hwheinzen/livedb"
 
 	. "github.com/hwheinzen/stringl10n/mistake"
 )
@@ -33,9 +32,20 @@ type Values struct {
 	Name    string
 	Acronym string // ASCII only
 	DbName  string // ASCII only
+	Version int    // schema version for migrations
+	Export  bool   // exported types and functions
+	JSONCase string // camel (default), snake or none
+	Tests   bool   // generate tests
+	HTTP    bool   // generate HTTP handler
+	OpenAPI bool   // generate OpenAPI document
+	Prefix  string // path prefix of the HTTP handler
+	TypeScript bool // generate TypeScript types
+	DDL     string // SQL dialect of DDL file: sqlite, postgresql or mysql
 	Atts    []Att
+	Tables  []Values // several tables sharing Copyright, Package, ErrorType and Export
 	// ------------- computed values
 	Generator string
+	Origin    string // registered with the table
 	Generated string
 	Input     string
 	UCPackage string
@@ -43,6 +53,19 @@ type Values struct {
 	UcAcronym string
 	UCAcronym string
 	LcName    string
+	UsesTime  bool
+	StdAtts   []Att // livedb.Std with JSON names in JSONCase, nil if unchanged
+	OldTag    string
+	NewTag    string
+	HasRules  bool
+	UsesUTF8  bool
+	UsesRegexp bool
+	TestFile  string
+	HTTPFile  string
+	OpenAPIFile string
+	TSFile    string
+	DDLFile   string
+	CanChange bool // samples differ
 	// ---
 	TypeTemplate string
 	NameTemplate string
@@ -57,13 +80,42 @@ type Att struct {
 	// ------------- from JSON file
 	Name          string
 	Type          string
+	Kind          string // see kinds
+	Nullable      bool   // pointer field, nil is NULL
 	IsNumType     bool
 	IsForeignType bool
 	DbName        string // ASCII only
 	CreateClause  string // ASCII only
 	ReadBy        bool
+	JSONName      string // name (and options) in JSON
+	Tags          string // further struct tags, e.g. db:"name"
+	Required      bool     // validation rules
+	MaxLen        int
+	Min           *float64
+	Max           *float64
+	Pattern       string
+	Enum          []string
+	Samples       []string // two Go expressions for generated tests
 	// ---
 	LcName        string
+	Tag           string
+	JSONField     string // name in JSON, "" if none
+	JSONOmit      bool   // omitted if empty
+	TSField       string // TypeScript property
+	TSType        string
+	Rules         []Rule
+	PatternVar    string
+	BaseType      string
+	Sample1       string
+	Sample2       string
+	GoType        string
+	NullType      string
+	NullField     string
+	Convert       string
+	ValCond       string
+	ValExpr       string
+	ArgType       string
+	ArgExpr       string
 }
 
 // buildtime serves 'l10n -version' if l10n was built with:
@@ -73,40 +125,67 @@ var buildtime string
 func main() {
 	fnc := "main"
 
-	jsonFile, lang := args(buildtime)
+	opts := args(buildtime)
 
-	vals, err := getValues(jsonFile)
+	if opts.tabName != "" {
+		err := fromDB(opts.tabName, opts.openString, opts.jsonFile)
+		if err != nil {
+			err = translate(err, opts.lang) // ******** l10n ********
+			log.Fatalln(pgm+":"+fnc+":"+err.Error())
+		}
+		return
+	}
+
+	all, err := getAllValues(opts)
 	if err != nil {
-		err = translate(err, lang) // ******** l10n ********
+		err = translate(err, opts.lang) // ******** l10n ********
 		log.Fatalln(pgm+":"+fnc+":"+err.Error())
 	}
+	if opts.reproducible || opts.check {
+		reproducible(&all)
+	}
 
-	err = makeCode(&vals)
+	files, err := generate(&all)
 	if err != nil {
-		err = translate(err, lang) // ******** l10n ********
+		err = translate(err, opts.lang) // ******** l10n ********
 		log.Fatalln(pgm+":"+fnc+":"+err.Error())
 	}
-	err = gofmt(vals.File)
+
+	if opts.check {
+		if status := check(os.Stderr, files, opts.lang); status != 0 {
+			os.Exit(status)
+		}
+		return
+	}
+
+	err = writeFiles(files)
 	if err != nil {
-		err = translate(err, lang) // ******** l10n ********
+		err = translate(err, opts.lang) // ******** l10n ********
 		log.Fatalln(pgm+":"+fnc+":"+err.Error())
 	}
 }
 
-func gofmt(fn string) error {
-	fnc := "gofmt"
+// getValues reads the JSON file jsonFile describing one table
+// and returns its checked and completed values.
+func getValues(jsonFile string) (Values, error) {
+	fnc := "getValues"
+
+	vals, err := decodeValues(jsonFile)
+	if err != nil {
+		return vals, fmt.Errorf(fnc+":%w", err)
+	}
 
-	cmd := exec.Command("gofmt", "-w", fn)
-	stdOutErr, err := cmd.CombinedOutput()
+	vals, err = checkValues(vals, jsonFile)
 	if err != nil {
-	   return fmt.Errorf(fnc+":"+string(stdOutErr)+"\n"+fnc+":%w", err)
+		return vals, fmt.Errorf(fnc+":%w", err)
 	}
 
-	return nil
+	return vals, nil
 }
 
-func getValues(jsonFile string) (vals Values, err error) {
-	fnc := "getValues"
+// decodeValues reads the JSON file jsonFile.
+func decodeValues(jsonFile string) (vals Values, err error) {
+	fnc := "decodeValues"
 
 	file, err := os.Open(jsonFile)
 	if err != nil {
@@ -134,6 +213,14 @@ func getValues(jsonFile string) (vals Values, err error) {
 		return vals, fmt.Errorf(fnc+":%w:"+err.Error(), e)
 	}
 
+	return vals, nil
+}
+
+// checkValues checks the values of one table read from jsonFile
+// and populates the computed values.
+func checkValues(vals Values, jsonFile string) (Values, error) {
+	fnc := "checkValues"
+
 	if vals.Copyright == "" {
 		err := Err{
 			Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is missing",
@@ -175,6 +262,16 @@ func getValues(jsonFile string) (vals Values, err error) {
 		}
 		return vals, fmt.Errorf(fnc+":%w", err)
 	}
+	if vals.Version < 0 {
+		err := Err{
+			Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} must not be negative",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", jsonFile},
+				{"Nam2", "Version"},
+			},
+		}
+		return vals, fmt.Errorf(fnc+":%w", err)
+	}
 	if len(vals.Acronym) < 2 {
 		err := Err{
 			Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} too short",
@@ -199,6 +296,68 @@ func getValues(jsonFile string) (vals Values, err error) {
 		}
 		vals.Atts[i].LcName = strings.ToLower(v.Name)
 
+		if !setKind(&vals.Atts[i]) {
+			err := Err{
+				Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid kind",
+				Var: []struct {Name  string; Value interface{}}{
+					{"Name", jsonFile},
+					{"Nam2", "Atts.Kind " + v.Kind},
+				},
+			}
+			return vals, fmt.Errorf(fnc+":%w", err)
+		}
+		if strings.TrimPrefix(vals.Atts[i].GoType, "*") == "time.Time" { // also Nullable
+			vals.UsesTime = true
+		}
+
+		if !setRules(&vals.Atts[i], strings.ToLower(vals.Acronym)) {
+			err := Err{
+				Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid value",
+				Var: []struct {Name  string; Value interface{}}{
+					{"Name", jsonFile},
+					{"Nam2", "validation rule of Atts.Name " + v.Name},
+				},
+			}
+			return vals, fmt.Errorf(fnc+":%w", err)
+		}
+		for _, r := range vals.Atts[i].Rules {
+			vals.HasRules = true
+			if strings.Contains(r.Cond, "utf8.") {
+				vals.UsesUTF8 = true
+			}
+		}
+		if vals.Atts[i].PatternVar != "" {
+			vals.UsesRegexp = true
+		}
+
+		if !setSamples(&vals.Atts[i]) {
+			err := Err{
+				Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid value",
+				Var: []struct {Name  string; Value interface{}}{
+					{"Name", jsonFile},
+					{"Nam2", "Atts.Samples of " + v.Name},
+				},
+			}
+			return vals, fmt.Errorf(fnc+":%w", err)
+		}
+		if vals.Atts[i].Sample1 != vals.Atts[i].Sample2 {
+			vals.CanChange = true
+		}
+
+		if !setTag(&vals.Atts[i], vals.JSONCase) {
+			err := Err{
+				Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid value",
+				Var: []struct {Name  string; Value interface{}}{
+					{"Name", jsonFile},
+					{"Nam2", "JSONCase " + vals.JSONCase + " or Atts.Tags " + v.Tags},
+				},
+			}
+			return vals, fmt.Errorf(fnc+":%w", err)
+		}
+		if vals.Atts[i].JSONField != "" {
+			setTSType(&vals.Atts[i])
+		}
+
 		if v.CreateClause == "" {
 			err := Err{
 				Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is missing",
@@ -214,20 +373,71 @@ func getValues(jsonFile string) (vals Values, err error) {
 	// populate rest of vals
 
 	vals.Generator = pgm
+	vals.Origin = pgm
+	if buildtime != "" {
+		vals.Origin += " " + buildtime
+	}
 	vals.Generated = time.Now().String()[:40]
 	vals.Input = jsonFile
 
 	vals.UCPackage = strings.ToUpper(vals.Package)
 
 	vals.LcAcronym = strings.ToLower(vals.Acronym)
-	vals.UcAcronym = strings.ToUpper(vals.Acronym[0:1]) + vals.Acronym[1:]
+	vals.UcAcronym = upperFirst(vals.Acronym)
 	vals.UCAcronym = strings.ToUpper(vals.Acronym)
 
 	vals.LcName = strings.ToLower(vals.Name)
+	if vals.LcName == vals.LcAcronym { // both name Go types
+		err := Err{
+			Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid value",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", jsonFile},
+				{"Nam2", "Acronym " + vals.Acronym + " (same as Name)"},
+			},
+		}
+		return vals, fmt.Errorf(fnc+":%w", err)
+	}
+
+	setStdAtts(&vals)
+	if vals.JSONCase != "none" {
+		vals.OldTag = "`json:\"old\"`"
+		vals.NewTag = "`json:\"new\"`"
+	}
 
 	if vals.File == "" {
 		vals.File = vals.LcAcronym + "_generated.go"
 	}
+	vals.TestFile = strings.TrimSuffix(vals.File, ".go") + "_test.go"
+	vals.HTTPFile = strings.TrimSuffix(vals.File, ".go") + "_http.go"
+	vals.OpenAPIFile = strings.TrimSuffix(vals.File, ".go") + openAPISuffix
+	vals.TSFile = strings.TrimSuffix(vals.File, ".go") + ".ts"
+	vals.DDLFile = strings.TrimSuffix(vals.File, ".go") + "_" + vals.DDL + ".sql"
+
+	if vals.DDL != "" && !isDialect(vals.DDL) {
+		err := Err{
+			Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid value",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", jsonFile},
+				{"Nam2", "DDL " + vals.DDL},
+			},
+		}
+		return vals, fmt.Errorf(fnc+":%w", err)
+	}
+
+	vals.Prefix = strings.TrimSuffix(vals.Prefix, "/")
+	if vals.Prefix == "" {
+		vals.Prefix = "/" + vals.LcName
+	}
+	if !validPrefix.MatchString(vals.Prefix) {
+		err := Err{
+			Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid value",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", jsonFile},
+				{"Nam2", "Prefix " + vals.Prefix},
+			},
+		}
+		return vals, fmt.Errorf(fnc+":%w", err)
+	}
 
 	if vals.DbName == "" {
 		vals.DbName = "t" + vals.LcName
@@ -239,6 +449,42 @@ func getValues(jsonFile string) (vals Values, err error) {
 		}
  	}
 
+	// livedb accepts identifiers only
+	if !livedb.IsIdent(vals.DbName) || len(vals.DbName) > livedb.MaxTableNameLen {
+		err := Err{
+			Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid identifier",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", jsonFile},
+				{"Nam2", "DbName " + vals.DbName},
+			},
+		}
+		return vals, fmt.Errorf(fnc+":%w", err)
+	}
+	for _, v := range vals.Atts {
+		if !livedb.IsIdent(v.DbName) {
+			err := Err{
+				Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is no valid identifier",
+				Var: []struct {Name  string; Value interface{}}{
+					{"Name", jsonFile},
+					{"Nam2", "Atts.DbName " + v.DbName},
+				},
+			}
+			return vals, fmt.Errorf(fnc+":%w", err)
+		}
+		if strings.Contains(v.CreateClause, ";") ||
+			strings.Contains(v.CreateClause, "--") ||
+			strings.Contains(v.CreateClause, "/*") {
+			err := Err{
+				Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} must not contain ; -- or /*",
+				Var: []struct {Name  string; Value interface{}}{
+					{"Name", jsonFile},
+					{"Nam2", "Atts.CreateClause " + v.CreateClause},
+				},
+			}
+			return vals, fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
 	vals.TypeTemplate = "{{.Type}}"
 	vals.NameTemplate = "{{.Name}}"
 	vals.Nam2Template = "{{.Nam2}}"
@@ -250,44 +496,3 @@ func getValues(jsonFile string) (vals Values, err error) {
 
 	return vals, nil
 }
-
-func makeCode(vals *Values) error {
-	fnc := "makeCode"
-
-	out, err := os.Create(vals.File)
-	if err != nil {
-		e := Err{
-			Fix: "GENERATELIVETAB:create file {{.Name}} failed",
-			Var: []struct {Name  string; Value interface{}}{
-				{"Name", vals.File},
-			},
-		}
-		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
-	}
-	defer out.Close()
-
-	t := template.New("tmpl")
-	_, err = t.Parse(tmpl)
-	if err != nil {
-		e := Err{
-			Fix: "GENERATELIVETAB:parse template {{.Name}} failed",
-			Var: []struct {Name  string; Value interface{}}{
-				{"Name", "tmpl"},
-			},
-		}
-		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
-	}
-
-	err = t.Execute(out, vals)
-	if err != nil {
-		e := Err{
-			Fix: "GENERATELIVETAB:execute template {{.Name}} failed",
-			Var: []struct {Name  string; Value interface{}}{
-				{"Name", "tmpl"},
-			},
-		}
-		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
-	}
-	
-	return nil
-}
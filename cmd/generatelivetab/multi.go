@@ -0,0 +1,238 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// allFile is the default name of the file with cross-table code.
+const allFile = "tables_generated.go"
+
+// allValues are the values of all tables generated in one run.
+type allValues struct {
+	File      string // "" -> single table, no cross-table code
+	Copyright string
+	Package   string
+	ErrorType string
+	Export    bool
+	// ---
+	Generator string
+	Generated string
+	Input     string
+	UCPackage string
+	Tables    []Values
+}
+
+// getAllValues returns the values of all tables described by the
+// JSON files opts.jsonFile names: a file, a directory (all *.json files in it)
+// or a glob pattern. A JSON file may describe several tables (see Tables),
+// which share its Copyright, Package, ErrorType, JSONCase, Export, Tests, HTTP, OpenAPI, TypeScript and DDL.
+// The flags in opts apply to all tables.
+//
+// Cross-table code is generated (File is set) unless pattern names
+// a single file describing a single table.
+func getAllValues(opts options) (all allValues, err error) {
+	fnc := "getAllValues"
+
+	pattern := opts.jsonFile
+
+	files, multi, err := jsonFiles(pattern)
+	if err != nil {
+		return all, fmt.Errorf(fnc+":%w", err)
+	}
+
+	for _, file := range files {
+		vals, err := decodeValues(file)
+		if err != nil {
+			return all, fmt.Errorf(fnc+":%w", err)
+		}
+
+		tabs := []Values{vals}
+		if len(vals.Tables) > 0 { // shared values
+			multi = true
+			if vals.File != "" {
+				all.File = vals.File
+			}
+			tabs = vals.Tables
+			for i := range tabs {
+				if tabs[i].Copyright == "" {
+					tabs[i].Copyright = vals.Copyright
+				}
+				if tabs[i].Package == "" {
+					tabs[i].Package = vals.Package
+				}
+				if tabs[i].ErrorType == "" {
+					tabs[i].ErrorType = vals.ErrorType
+				}
+				if tabs[i].JSONCase == "" {
+					tabs[i].JSONCase = vals.JSONCase
+				}
+				tabs[i].Export = tabs[i].Export || vals.Export
+				tabs[i].Tests = tabs[i].Tests || vals.Tests
+				tabs[i].HTTP = tabs[i].HTTP || vals.HTTP
+				tabs[i].OpenAPI = tabs[i].OpenAPI || vals.OpenAPI
+				tabs[i].TypeScript = tabs[i].TypeScript || vals.TypeScript
+				if tabs[i].DDL == "" {
+					tabs[i].DDL = vals.DDL
+				}
+			}
+		}
+
+		for _, tab := range tabs {
+			tab.Export = tab.Export || opts.export
+			tab.Tests = tab.Tests || opts.tests
+			tab.HTTP = tab.HTTP || opts.http
+			tab.OpenAPI = tab.OpenAPI || opts.openapi
+			tab.TypeScript = tab.TypeScript || opts.ts
+			if opts.ddl != "" {
+				tab.DDL = opts.ddl
+			}
+			tab, err = checkValues(tab, file)
+			if err != nil {
+				return all, fmt.Errorf(fnc+":%w", err)
+			}
+			all.Tables = append(all.Tables, tab)
+		}
+	}
+
+	first := all.Tables[0]
+	all.Copyright = first.Copyright
+	all.Package = first.Package
+	all.ErrorType = first.ErrorType
+	all.Export = first.Export
+	all.Generator = pgm
+	all.Generated = time.Now().String()[:40]
+	all.Input = pattern
+	all.UCPackage = first.UCPackage
+
+	if !multi && len(all.Tables) == 1 {
+		all.File = ""
+		return all, nil
+	}
+	if all.File == "" {
+		all.File = allFile
+	}
+
+	err = checkAllValues(&all)
+	if err != nil {
+		return all, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return all, nil
+}
+
+// jsonFiles returns the JSON files pattern names and reports
+// whether pattern is a directory or glob pattern.
+// Directories and glob patterns skip generated OpenAPI documents.
+func jsonFiles(pattern string) (files []string, multi bool, err error) {
+	fnc := "jsonFiles"
+
+	info, err := os.Stat(pattern)
+	switch {
+	case err == nil && !info.IsDir():
+		return []string{pattern}, false, nil
+	case err == nil:
+		files, err = filepath.Glob(filepath.Join(pattern, "*.json"))
+	default:
+		files, err = filepath.Glob(pattern)
+	}
+	var inputs []string
+	for _, file := range files {
+		if !strings.HasSuffix(file, openAPISuffix) { // generated
+			inputs = append(inputs, file)
+		}
+	}
+	files = inputs
+	if err != nil || len(files) == 0 {
+		e := Err{
+			Fix: "GENERATELIVETAB:no JSON file matches {{.Name}}",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", pattern},
+			},
+		}
+		if err != nil {
+			return nil, true, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+		return nil, true, fmt.Errorf(fnc+":%w", e)
+	}
+
+	return files, true, nil
+}
+
+// checkAllValues checks that the tables fit together:
+// they share Package, ErrorType and Export (consistent error prefixes
+// and names), their Acronyms, Names, DbNames, Files and Prefixes are unique.
+func checkAllValues(all *allValues) error {
+	fnc := "checkAllValues"
+
+	first := all.Tables[0]
+	used := map[string]string{} // "Name value" -> JSON file
+	for _, vals := range all.Tables {
+		for _, v := range []struct{ Name, Value, First string }{
+			{"Package", vals.Package, first.Package},
+			{"ErrorType", vals.ErrorType, first.ErrorType},
+			{"Export", fmt.Sprint(vals.Export), fmt.Sprint(first.Export)},
+		} {
+			if v.Value != v.First {
+				err := Err{
+					Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} differs from {{.Nam3}}",
+					Var: []struct {Name  string; Value interface{}}{
+						{"Name", vals.Input},
+						{"Nam2", v.Name + " " + v.Value},
+						{"Nam3", first.Input},
+					},
+				}
+				return fmt.Errorf(fnc+":%w", err)
+			}
+		}
+
+		for _, key := range []string{
+			"Acronym/Name " + vals.LcAcronym, // one namespace: both name Go types,
+			"Acronym/Name " + vals.LcName,    // case matters not
+			"DbName " + vals.DbName,
+			"File " + vals.File,
+			"File " + vals.TestFile,
+			"File " + vals.HTTPFile,
+			"File " + vals.OpenAPIFile,
+			"File " + vals.TSFile,
+			"File " + vals.DDLFile,
+			"Prefix " + vals.Prefix,
+		} {
+			if input, ok := used[key]; ok {
+				err := Err{
+					Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is used in {{.Nam3}} already",
+					Var: []struct {Name  string; Value interface{}}{
+						{"Name", vals.Input},
+						{"Nam2", key},
+						{"Nam3", input},
+					},
+				}
+				return fmt.Errorf(fnc+":%w", err)
+			}
+			used[key] = vals.Input
+		}
+	}
+
+	if input, ok := used["File "+all.File]; ok {
+		err := Err{
+			Fix: "GENERATELIVETAB:{{.Nam2}} in {{.Name}} is used in {{.Nam3}} already",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", input},
+				{"Nam2", "File " + all.File},
+				{"Nam3", all.Input},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for several tables.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestDirTwice tests that a directory is generated twice (and checked)
+// without taking the generated OpenAPI documents as input.
+func TestDirTwice(t *testing.T) {
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	err = os.Chdir(dir) // generated files go to the working directory
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	for name, acr := range map[string]string{"Mitarbeiter": "Mi", "Konto": "Ko"} {
+		err = os.WriteFile(filepath.Join(dir, acr+".json"), []byte(`{
+	"Copyright":  "2021 Itts Mee"
+	,"Package":   "example"
+	,"ErrorType": "Err"
+	,"Name":      "`+name+`"
+	,"Acronym":   "`+acr+`"
+	,"Atts":      [
+		{"Name": "Name", "CreateClause": "varchar(50) not null"}
+	]
+}`), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for run := 1; run <= 2; run++ {
+		all, err := getAllValues(options{jsonFile: ".", http: true, openapi: true}) // <------- ACTION
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal("run", run, err)
+		}
+		if len(all.Tables) != 2 {
+			t.Fatal("run", run, "expected 2 tables, got", len(all.Tables))
+		}
+		reproducible(&all) // as with -check
+		files, err := generate(&all)
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal("run", run, err)
+		}
+
+		if run == 2 { // -check
+			if stale := staleFiles(files); len(stale) > 0 {
+				t.Fatal("run", run, "expected no stale files, got", stale)
+			}
+			break
+		}
+		err = writeFiles(files)
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal("run", run, err)
+		}
+	}
+
+	if _, err := os.Stat("mi_generated_openapi.json"); err != nil {
+		t.Error("expected OpenAPI document:", err)
+	}
+}
+
+// TestTypeCollision tests that Acronyms and Names of all tables
+// are refused if they would name the same Go type.
+func TestTypeCollision(t *testing.T) {
+
+	type collisionTest struct {
+		tables map[string]string // Name -> Acronym
+		//
+		ok bool
+	}
+	collisionTests := []collisionTest{
+		{map[string]string{"Mitarbeiter": "Mi", "Konto": "Ko"}, true},
+		{map[string]string{"Mi": "Mx", "Mitarbeiter": "Mi"}, false},    // Name of one, Acronym of other
+		{map[string]string{"Mi": "Mx", "MI": "Ko"}, false},             // Names
+		{map[string]string{"Mitarbeiter": "Mi", "Konto": "mi"}, false}, // Acronyms
+		{map[string]string{"Mi": "mi"}, false},                         // Name and Acronym of one
+	}
+
+	for i, v := range collisionTests {
+		dir := t.TempDir()
+		n := 0
+		for name, acr := range v.tables {
+			n++
+			err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("t%d.json", n)), []byte(`{
+	"Copyright":  "2021 Itts Mee"
+	,"Package":   "example"
+	,"ErrorType": "Err"
+	,"Name":      "`+name+`"
+	,"Acronym":   "`+acr+`"
+	,"DbName":    "t`+fmt.Sprint(n)+`"
+	,"Atts":      [
+		{"Name": "Name", "CreateClause": "varchar(50) not null"}
+	]
+}`), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_, err := getAllValues(options{jsonFile: dir}) // <------- ACTION
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
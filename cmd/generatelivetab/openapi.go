@@ -0,0 +1,292 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// openAPISuffix ends the names of the generated OpenAPI documents.
+const openAPISuffix = "_openapi.json"
+
+// validPrefix matches path prefixes like "/api/mitarbeiter".
+var validPrefix = regexp.MustCompile(`^(/[\p{L}\p{N}._~-]+)+$`)
+
+// object is a JSON object of the OpenAPI document;
+// encoding/json writes its keys sorted.
+type object map[string]interface{}
+
+// makeOpenAPI returns the OpenAPI document of vals (OpenAPIFile).
+func makeOpenAPI(vals *Values) ([]byte, error) {
+	fnc := "makeOpenAPI"
+
+	b, err := json.MarshalIndent(openAPI(vals), "", "\t")
+	if err != nil {
+		e := Err{
+			Fix: "GENERATELIVETAB:create file {{.Name}} failed",
+			Var: []struct {Name  string; Value interface{}}{
+				{"Name", vals.OpenAPIFile},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return append(b, '\n'), nil
+}
+
+// openAPI returns the OpenAPI 3 document describing the API
+// served by the generated handler of vals (see httpTmpl).
+//
+// Schemas are Std (livedb.Std), <Acronym> (the specific attributes),
+// <Name> (both), <Acronym>Pair and Error.
+func openAPI(vals *Values) object {
+	ref := func(name string) object {
+		return object{"$ref": "#/components/schemas/" + name}
+	}
+	content := func(schema object) object {
+		return object{"application/json": object{"schema": schema}}
+	}
+	list := func(schema object) object {
+		return object{"type": "array", "items": schema}
+	}
+	resp := func(desc string, schema object) object {
+		r := object{"description": desc}
+		if schema != nil {
+			r["content"] = content(schema)
+		}
+		return r
+	}
+	fail := func(desc string) object {
+		return resp(desc, ref("Error"))
+	}
+
+	acr, name := vals.UcAcronym, vals.Name
+	rec := ref(name)
+
+	tsParam := object{
+		"name":        "ts",
+		"in":          "query",
+		"description": "timestamp, default: now",
+		"schema":      object{"type": "string"},
+	}
+	idParam := object{
+		"name":     "id",
+		"in":       "path",
+		"required": true,
+		"schema":   object{"type": "integer"},
+	}
+
+	paths := object{
+		vals.Prefix: object{
+			"get": object{
+				"operationId": "read" + acr + "s",
+				"summary":     "all " + name + "s valid at ts",
+				"parameters":  []object{tsParam},
+				"responses": object{
+					"200":     resp("valid records", list(rec)),
+					"400":     fail("invalid timestamp"),
+					"default": fail("error"),
+				},
+			},
+			"post": object{
+				"operationId": "start" + acr,
+				"summary":     "starts a new " + name + " at ts",
+				"parameters":  []object{tsParam},
+				"requestBody": object{"required": true, "content": content(ref(acr))},
+				"responses": object{
+					"201": object{
+						"description": "started record",
+						"headers": object{
+							"Location": object{
+								"description": "path of the new " + name,
+								"schema":      object{"type": "string"},
+							},
+						},
+						"content": content(rec),
+					},
+					"400":     fail("invalid timestamp or attributes"),
+					"409":     fail("conflict"),
+					"default": fail("error"),
+				},
+			},
+		},
+		vals.Prefix + "/{id}": object{
+			"parameters": []object{idParam},
+			"get": object{
+				"operationId": "read" + acr,
+				"summary":     "the " + name + " valid at ts",
+				"parameters":  []object{tsParam},
+				"responses": object{
+					"200":     resp("valid record", rec),
+					"400":     fail("invalid id or timestamp"),
+					"404":     fail("not found"),
+					"default": fail("error"),
+				},
+			},
+			"put": object{
+				"operationId": "change" + acr,
+				"summary":     "changes the " + name + " at ts from old (as read) to new",
+				"parameters":  []object{tsParam},
+				"requestBody": object{"required": true, "content": content(ref(acr + "Pair"))},
+				"responses": object{
+					"200":     resp("changed record", rec),
+					"400":     fail("invalid id, timestamp or attributes"),
+					"404":     fail("not found"),
+					"409":     fail("old is outdated"),
+					"default": fail("error"),
+				},
+			},
+			"delete": object{
+				"operationId": "terminate" + acr,
+				"summary":     "terminates the " + name + " valid at ts",
+				"parameters":  []object{tsParam},
+				"responses": object{
+					"204":     resp("terminated", nil),
+					"400":     fail("invalid id or timestamp"),
+					"404":     fail("not found"),
+					"409":     fail("conflict"),
+					"default": fail("error"),
+				},
+			},
+		},
+		vals.Prefix + "/{id}/history": object{
+			"parameters": []object{idParam},
+			"get": object{
+				"operationId": "history" + acr,
+				"summary":     "all records of the " + name,
+				"responses": object{
+					"200":     resp("records", list(rec)),
+					"400":     fail("invalid id"),
+					"404":     fail("not found"),
+					"default": fail("error"),
+				},
+			},
+		},
+	}
+
+	atts := object{}
+	var required []string
+	for _, att := range vals.Atts {
+		if att.JSONField == "" {
+			continue
+		}
+		atts[att.JSONField] = attSchema(att)
+		if att.Required {
+			required = append(required, att.JSONField)
+		}
+	}
+	attsSchema := object{"type": "object", "properties": atts}
+	if len(required) > 0 {
+		attsSchema["required"] = required
+	}
+
+	oldName, newName := "Old", "New"
+	if vals.OldTag != "" {
+		oldName, newName = "old", "new"
+	}
+
+	tmsp := object{"type": "string", "description": "timestamp, e.g. 2021-03-04 05:06:07.000"}
+	createdBy, endedBy := vals.StdJSON("CreatedBy"), vals.StdJSON("EndedBy")
+	schemas := object{
+		"Std": object{
+			"type": "object",
+			"properties": object{
+				"id":        object{"type": "integer"},
+				"begin":     tmsp,
+				"until":     tmsp,
+				"pkey":      object{"type": "integer"},
+				"created":   tmsp,
+				createdBy:   object{"type": "string"},
+				"ended":     tmsp,
+				endedBy:     object{"type": "string"},
+			},
+			"required": []string{"id", "begin", "until", "pkey", "created", createdBy},
+		},
+		acr:  attsSchema,
+		name: object{"allOf": []object{ref("Std"), ref(acr)}},
+		acr + "Pair": object{
+			"type":       "object",
+			"properties": object{oldName: rec, newName: rec},
+			"required":   []string{oldName, newName},
+		},
+		"Error": object{
+			"type": "object",
+			"properties": object{
+				"code":  object{"type": "string", "enum": []string{"invalid", "not_found", "conflict", "internal"}},
+				"error": object{"type": "string", "description": "translated message"},
+			},
+			"required": []string{"code", "error"},
+		},
+	}
+
+	return object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":       name,
+			"version":     strconv.Itoa(vals.Version),
+			"description": "livedb table " + vals.DbName + ", generated by " + vals.Generator + " using " + vals.Input,
+		},
+		"paths":      paths,
+		"components": object{"schemas": schemas},
+	}
+}
+
+// attSchema returns the schema of att fitting its Go type
+// as encoded by encoding/json and its validation rules.
+func attSchema(att Att) object {
+	s := object{"type": "string"}
+	switch att.Kind {
+	case "int":
+		s["type"] = "integer"
+	case "float":
+		s["type"] = "number"
+		s["format"] = "double"
+	case "bool":
+		s["type"] = "boolean"
+	case "decimal":
+		s["format"] = "decimal"
+	case "date", "timestamp": // time.Time
+		s["format"] = "date-time"
+	case "bytes": // base64
+		s["format"] = "byte"
+	}
+	nullable := att.Nullable && !att.Required // Required excludes nil
+	if nullable {
+		s["nullable"] = true
+	}
+
+	if att.MaxLen > 0 {
+		s["maxLength"] = att.MaxLen
+	}
+	if att.Min != nil {
+		s["minimum"] = *att.Min
+	}
+	if att.Max != nil {
+		s["maximum"] = *att.Max
+	}
+	if att.Pattern != "" { // empty strings are not checked
+		s["pattern"] = "^$|" + att.Pattern
+	}
+	if len(att.Enum) > 0 {
+		var enum []interface{}
+		for _, e := range att.Enum {
+			enum = append(enum, e)
+		}
+		if !att.Required { // empty strings are not checked
+			enum = append(enum, "")
+		}
+		if nullable {
+			enum = append(enum, nil)
+		}
+		s["enum"] = enum
+	}
+
+	return s
+}
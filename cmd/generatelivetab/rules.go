@@ -0,0 +1,137 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Rule is a validation rule of an attribute as used by the template.
+type Rule struct {
+	Cond string // Go condition true if x violates the rule
+	Fix  string // message of the violation without "<PACKAGE>:"
+	Var  []RuleVar
+}
+
+// RuleVar is a variable of the message of a Rule.
+type RuleVar struct {
+	Name  string
+	Value string // Go expression
+}
+
+// lenClause matches the length of varchar(n), char(n) etc.
+var lenClause = regexp.MustCompile(`(?i)^\s*(?:var)?char(?:acter)?(?:\s+varying)?\s*\(\s*(\d+)\s*\)`)
+
+// setRules computes the Rules of att from Required, MaxLen, Min, Max,
+// Pattern and Enum. It needs the values computed by setKind.
+// It reports false if a rule does not fit the Kind of att.
+//
+// The messages have the attribute name in Name and
+// the limit in Int (MaxLen) or Nam2 (Min, Max, Pattern, Enum).
+//
+// MaxLen of string attributes defaults to the length in CreateClause.
+// Min and Max apply to int and float attributes.
+// Pattern and Enum apply to string attributes which are not empty.
+func setRules(att *Att, lcAcronym string) bool {
+	att.Rules = nil
+	field := "x." + att.Name
+	value := field // non-pointer value
+	present := ""  // condition for value being present
+	if att.Nullable && att.Kind != "bytes" {
+		value = "*" + field
+		present = field + " != nil && "
+	}
+	str := "string(" + value + ")"
+	add := func(cond, fix string, limit ...RuleVar) {
+		att.Rules = append(att.Rules, Rule{
+			Cond: cond,
+			Fix:  fix,
+			Var:  append([]RuleVar{{"Name", fmt.Sprintf("%q", att.Name)}}, limit...),
+		})
+	}
+	nam2 := func(limit string) RuleVar {
+		return RuleVar{"Nam2", fmt.Sprintf("%q", limit)}
+	}
+
+	isString := att.Kind == "string"
+	isNum := att.Kind == "int" || att.Kind == "float"
+
+	if att.Required {
+		required := "{{.Name}} is required"
+		switch {
+		case att.Kind == "bytes":
+			add("len("+field+") == 0", required)
+		case att.Nullable:
+			add(field+" == nil", required)
+		case isString || att.Kind == "decimal":
+			add(field+` == ""`, required)
+		case isNum:
+			add(field+" == 0", required)
+		case att.Kind == "date" || att.Kind == "timestamp":
+			add(field+".IsZero()", required)
+		default: // bool
+			return false
+		}
+	}
+
+	if att.MaxLen == 0 && isString {
+		if m := lenClause.FindStringSubmatch(att.CreateClause); m != nil {
+			att.MaxLen, _ = strconv.Atoi(m[1])
+		}
+	}
+	if att.MaxLen > 0 {
+		if !isString {
+			return false
+		}
+		n := strconv.Itoa(att.MaxLen)
+		add(present+"utf8.RuneCountInString("+str+") > "+n,
+			"{{.Name}} is longer than {{.Int}} characters", RuleVar{"Int", n})
+	}
+
+	for _, v := range []struct {
+		limit *float64
+		op    string
+		fix   string
+	}{
+		{att.Min, "<", "{{.Name}} is less than {{.Nam2}}"},
+		{att.Max, ">", "{{.Name}} is greater than {{.Nam2}}"},
+	} {
+		if v.limit == nil {
+			continue
+		}
+		if !isNum {
+			return false
+		}
+		limit := strconv.FormatFloat(*v.limit, 'g', -1, 64)
+		add(present+"float64("+value+") "+v.op+" "+limit, v.fix, nam2(limit))
+	}
+
+	if att.Pattern != "" {
+		_, err := regexp.Compile(att.Pattern)
+		if err != nil || !isString {
+			return false
+		}
+		att.PatternVar = lcAcronym + att.Name + "Pattern"
+		add(present+str+` != "" && !`+att.PatternVar+".MatchString("+str+")",
+			"{{.Name}} does not match {{.Nam2}}", nam2(att.Pattern))
+	}
+
+	if len(att.Enum) > 0 {
+		if !isString {
+			return false
+		}
+		var eqs []string
+		for _, e := range att.Enum {
+			eqs = append(eqs, str+" == "+fmt.Sprintf("%q", e))
+		}
+		add(present+str+` != "" && !(`+strings.Join(eqs, " || ")+")",
+			"{{.Name}} is not one of {{.Nam2}}", nam2(strings.Join(att.Enum, "|")))
+	}
+
+	return true
+}
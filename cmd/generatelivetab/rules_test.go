@@ -0,0 +1,111 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for validation rules.
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestSetRules tests the rules of each kind and the rules not fitting a kind.
+func TestSetRules(t *testing.T) {
+
+	limit := func(f float64) *float64 { return &f }
+	name := func(n string) RuleVar { return RuleVar{"Name", `"` + n + `"`} }
+	required := "{{.Name}} is required"
+
+	type rulesTest struct {
+		att Att
+		//
+		ok    bool
+		rules []Rule
+	}
+	rulesTests := []rulesTest{
+		// Required
+		{Att{Name: "S", Kind: "string", Required: true}, true, []Rule{
+			{`x.S == ""`, required, []RuleVar{name("S")}},
+		}},
+		{Att{Name: "D", Kind: "decimal", Required: true}, true, []Rule{
+			{`x.D == ""`, required, []RuleVar{name("D")}},
+		}},
+		{Att{Name: "I", Kind: "int", Required: true}, true, []Rule{
+			{"x.I == 0", required, []RuleVar{name("I")}},
+		}},
+		{Att{Name: "F", Kind: "float", Required: true}, true, []Rule{
+			{"x.F == 0", required, []RuleVar{name("F")}},
+		}},
+		{Att{Name: "T", Kind: "date", Required: true}, true, []Rule{
+			{"x.T.IsZero()", required, []RuleVar{name("T")}},
+		}},
+		{Att{Name: "T", Kind: "timestamp", Required: true}, true, []Rule{
+			{"x.T.IsZero()", required, []RuleVar{name("T")}},
+		}},
+		{Att{Name: "B", Kind: "bytes", Required: true, Nullable: true}, true, []Rule{
+			{"len(x.B) == 0", required, []RuleVar{name("B")}},
+		}},
+		{Att{Name: "P", Kind: "int", Required: true, Nullable: true}, true, []Rule{
+			{"x.P == nil", required, []RuleVar{name("P")}},
+		}},
+		{Att{Name: "B", Kind: "bool", Required: true}, false, nil},
+		// MaxLen
+		{Att{Name: "S", Kind: "string", MaxLen: 5}, true, []Rule{
+			{"utf8.RuneCountInString(string(x.S)) > 5",
+				"{{.Name}} is longer than {{.Int}} characters", []RuleVar{name("S"), {"Int", "5"}}},
+		}},
+		{Att{Name: "S", Kind: "string", CreateClause: "varchar(50) not null"}, true, []Rule{
+			{"utf8.RuneCountInString(string(x.S)) > 50",
+				"{{.Name}} is longer than {{.Int}} characters", []RuleVar{name("S"), {"Int", "50"}}},
+		}},
+		{Att{Name: "S", Kind: "string", Nullable: true, CreateClause: "character varying (7)"}, true, []Rule{
+			{"x.S != nil && utf8.RuneCountInString(string(*x.S)) > 7",
+				"{{.Name}} is longer than {{.Int}} characters", []RuleVar{name("S"), {"Int", "7"}}},
+		}},
+		{Att{Name: "S", Kind: "string", CreateClause: "text"}, true, nil},
+		{Att{Name: "I", Kind: "int", MaxLen: 5}, false, nil},
+		// Min, Max
+		{Att{Name: "I", Kind: "int", Min: limit(1), Max: limit(99)}, true, []Rule{
+			{"float64(x.I) < 1", "{{.Name}} is less than {{.Nam2}}", []RuleVar{name("I"), {"Nam2", `"1"`}}},
+			{"float64(x.I) > 99", "{{.Name}} is greater than {{.Nam2}}", []RuleVar{name("I"), {"Nam2", `"99"`}}},
+		}},
+		{Att{Name: "F", Kind: "float", Nullable: true, Min: limit(0.5)}, true, []Rule{
+			{"x.F != nil && float64(*x.F) < 0.5", "{{.Name}} is less than {{.Nam2}}", []RuleVar{name("F"), {"Nam2", `"0.5"`}}},
+		}},
+		{Att{Name: "S", Kind: "string", Min: limit(1)}, false, nil},
+		{Att{Name: "D", Kind: "decimal", Max: limit(1)}, false, nil},
+		// Pattern
+		{Att{Name: "S", Kind: "string", Pattern: "^[A-Z]"}, true, []Rule{
+			{`string(x.S) != "" && !koSPattern.MatchString(string(x.S))`,
+				"{{.Name}} does not match {{.Nam2}}", []RuleVar{name("S"), {"Nam2", `"^[A-Z]"`}}},
+		}},
+		{Att{Name: "S", Kind: "string", Pattern: "[A-Z"}, false, nil}, // no regexp
+		{Att{Name: "I", Kind: "int", Pattern: "^1"}, false, nil},
+		// Enum
+		{Att{Name: "S", Kind: "string", Nullable: true, Enum: []string{"a", "b"}}, true, []Rule{
+			{`x.S != nil && string(*x.S) != "" && !(string(*x.S) == "a" || string(*x.S) == "b")`,
+				"{{.Name}} is not one of {{.Nam2}}", []RuleVar{name("S"), {"Nam2", `"a|b"`}}},
+		}},
+		{Att{Name: "T", Kind: "date", Enum: []string{"a"}}, false, nil},
+		// no rules
+		{Att{Name: "B", Kind: "bool"}, true, nil},
+	}
+
+	for i, v := range rulesTests {
+		att := v.att
+		ok := setRules(&att, "ko") // <------- ACTION
+		switch {
+		case ok != v.ok:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.ok, "got", ok)
+		case !ok:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, false expected")
+		case !reflect.DeepEqual(att.Rules, v.rules):
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.rules, "got", att.Rules)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
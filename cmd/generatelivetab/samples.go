@@ -0,0 +1,65 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// setSamples computes two different sample values of att (Go expressions
+// of type BaseType) for generated tests, fitting Kind and validation rules.
+// It needs the values computed by setKind and setRules.
+// It reports false if Samples are given but not exactly two.
+func setSamples(att *Att) bool {
+	att.BaseType = strings.TrimPrefix(att.GoType, "*")
+
+	if att.Samples != nil {
+		if len(att.Samples) != 2 {
+			return false
+		}
+		att.Sample1, att.Sample2 = att.Samples[0], att.Samples[1]
+		return true
+	}
+
+	switch att.Kind {
+	case "int", "float":
+		a, b := 1.0, 2.0
+		if att.Min != nil {
+			a, b = *att.Min, *att.Min+1
+		}
+		if att.Max != nil {
+			b = math.Min(b, *att.Max)
+			a = math.Min(a, b)
+		}
+		if att.Kind == "int" {
+			a, b = math.Ceil(a), math.Floor(b)
+		}
+		att.Sample1 = strconv.FormatFloat(a, 'f', -1, 64)
+		att.Sample2 = strconv.FormatFloat(b, 'f', -1, 64)
+	case "bool":
+		att.Sample1, att.Sample2 = "true", "false"
+	case "decimal":
+		att.Sample1, att.Sample2 = `"1.5"`, `"2.5"`
+	case "date":
+		att.Sample1 = "time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)"
+		att.Sample2 = "time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC)"
+	case "timestamp":
+		att.Sample1 = "time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)"
+		att.Sample2 = "time.Date(2021, 3, 5, 6, 7, 8, 0, time.UTC)"
+	case "bytes":
+		att.Sample1, att.Sample2 = `[]byte("a")`, `[]byte("b")`
+	default: // string
+		att.Sample1, att.Sample2 = `"a"`, `"b"`
+		if len(att.Enum) > 0 {
+			att.Sample1 = fmt.Sprintf("%q", att.Enum[0])
+			att.Sample2 = fmt.Sprintf("%q", att.Enum[len(att.Enum)-1])
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,164 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// jsonCases maps the JSONCases to functions computing JSON names
+// from field names; nil means no JSON names.
+var jsonCases = map[string]func(string) string{
+	"camel": camelCase,
+	"snake": snakeCase,
+	"none":  nil,
+}
+
+// validTags matches struct tags like `key:"value" key2:"value2"`.
+var validTags = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*:"[^"` + "`" + `]*"( +[A-Za-z_][A-Za-z0-9_]*:"[^"` + "`" + `]*")*$`)
+
+// setTag computes the struct tag of att (with backquotes, "" if none).
+// It reports false if jsonCase is unknown or Tags are malformed.
+//
+// The JSON name is JSONName, else the Name in jsonCase;
+// it is omitted if empty when att is Nullable.
+// Tags containing a json key replace the JSON name.
+func setTag(att *Att, jsonCase string) bool {
+	if jsonCase == "" {
+		jsonCase = "camel"
+	}
+	toJSON, ok := jsonCases[jsonCase]
+	if !ok {
+		return false
+	}
+	if att.Tags != "" && !validTags.MatchString(att.Tags) {
+		return false
+	}
+
+	var tags []string
+	_, hasJSON := reflect.StructTag(att.Tags).Lookup("json")
+	switch {
+	case hasJSON:
+	case att.JSONName != "":
+		tags = append(tags, `json:"`+att.JSONName+`"`)
+	case toJSON != nil && att.Nullable:
+		tags = append(tags, `json:"`+toJSON(att.Name)+`,omitempty"`)
+	case toJSON != nil:
+		tags = append(tags, `json:"`+toJSON(att.Name)+`"`)
+	}
+	if att.Tags != "" {
+		tags = append(tags, att.Tags)
+	}
+
+	att.Tag = ""
+	if len(tags) > 0 {
+		att.Tag = "`" + strings.Join(tags, " ") + "`"
+	}
+
+	// name as encoding/json uses it
+	att.JSONField, att.JSONOmit = att.Name, false
+	if tag, ok := reflect.StructTag(strings.Join(tags, " ")).Lookup("json"); ok {
+		opts := strings.Split(tag, ",")
+		switch {
+		case tag == "-":
+			att.JSONField = ""
+		case opts[0] != "":
+			att.JSONField = opts[0]
+		}
+		for _, o := range opts[1:] {
+			att.JSONOmit = att.JSONOmit || o == "omitempty"
+		}
+	}
+
+	return true
+}
+
+// camelCase returns name with leading upper case letters in lower case,
+// but the last one before a lower case letter:
+// "Name" -> "name", "UsID" -> "usID", "URLPath" -> "urlPath".
+func camelCase(name string) string {
+	rs := []rune(name)
+	for i := range rs {
+		if !unicode.IsUpper(rs[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(rs) && unicode.IsLower(rs[i+1]) {
+			break
+		}
+		rs[i] = unicode.ToLower(rs[i])
+	}
+	return string(rs)
+}
+
+// snakeCase returns name in lower case with words separated by underscores:
+// "Name" -> "name", "UsID" -> "us_id", "URLPath" -> "url_path".
+func snakeCase(name string) string {
+	rs := []rune(name)
+	var b strings.Builder
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) && rs[i-1] != '_' &&
+			(!unicode.IsUpper(rs[i-1]) || i+1 < len(rs) && unicode.IsLower(rs[i+1])) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// stdAtts are the fields of livedb.Std with their JSON names there.
+var stdAtts = []Att{
+	{Name: "ID", GoType: "int", JSONField: "id"},
+	{Name: "Begin", GoType: "string", JSONField: "begin"},
+	{Name: "Until", GoType: "string", JSONField: "until"},
+	{Name: "Pkey", GoType: "int", JSONField: "pkey"},
+	{Name: "Created", GoType: "string", JSONField: "created"},
+	{Name: "CreatedBy", GoType: "string", JSONField: "createdBy"},
+	{Name: "Ended", GoType: "string", Nullable: true, JSONField: "ended", JSONOmit: true},
+	{Name: "EndedBy", GoType: "string", Nullable: true, JSONField: "endedBy", JSONOmit: true},
+}
+
+// setStdAtts sets StdAtts to the fields of livedb.Std tagged in JSONCase
+// if any JSON name differs from livedb.Std, else to nil.
+// JSONCase none keeps the JSON names of livedb.Std.
+func setStdAtts(vals *Values) {
+	vals.StdAtts = nil
+	jsonCase := vals.JSONCase
+	if jsonCase == "" {
+		jsonCase = "camel"
+	}
+	if jsonCases[jsonCase] == nil {
+		return
+	}
+
+	atts := make([]Att, len(stdAtts))
+	differ := false
+	for i, a := range stdAtts {
+		atts[i] = Att{Name: a.Name, GoType: a.GoType, Nullable: a.Nullable}
+		setTag(&atts[i], jsonCase)
+		differ = differ || atts[i].JSONField != a.JSONField
+	}
+	if differ {
+		vals.StdAtts = atts
+	}
+}
+
+// StdJSON returns the JSON name of the field name of livedb.Std
+// in the generated code.
+func (vals Values) StdJSON(name string) string {
+	for _, a := range vals.StdAtts {
+		if a.Name == name {
+			return a.JSONField
+		}
+	}
+	for _, a := range stdAtts {
+		if a.Name == name {
+			return a.JSONField
+		}
+	}
+	return ""
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for struct tags.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestCamelCase tests JSON names in camel case.
+func TestCamelCase(t *testing.T) {
+
+	type caseTest struct {
+		name string
+		//
+		want string
+	}
+	caseTests := []caseTest{
+		{"Name", "name"},
+		{"UsID", "usID"},
+		{"URLPath", "urlPath"},
+		{"ID", "id"},
+		{"CreatedBy", "createdBy"},
+		{"name", "name"},
+		{"X", "x"},
+		{"Übername", "übername"},
+		{"", ""},
+	}
+
+	for i, v := range caseTests {
+		got := camelCase(v.name) // <------- ACTION
+		if got != v.want {
+			t.Error("#"+fmt.Sprintf("%d", i+1), v.name, "expected", v.want, "got", got)
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+}
+
+// TestSnakeCase tests JSON names in snake case.
+func TestSnakeCase(t *testing.T) {
+
+	type caseTest struct {
+		name string
+		//
+		want string
+	}
+	caseTests := []caseTest{
+		{"Name", "name"},
+		{"UsID", "us_id"},
+		{"URLPath", "url_path"},
+		{"ID", "id"},
+		{"CreatedBy", "created_by"},
+		{"Us_ID", "us_id"},
+		{"name", "name"},
+		{"X", "x"},
+		{"ÜberName", "über_name"},
+		{"", ""},
+	}
+
+	for i, v := range caseTests {
+		got := snakeCase(v.name) // <------- ACTION
+		if got != v.want {
+			t.Error("#"+fmt.Sprintf("%d", i+1), v.name, "expected", v.want, "got", got)
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+}
+
+// TestValidTags tests which Tags are accepted.
+func TestValidTags(t *testing.T) {
+
+	type tagsTest struct {
+		tags string
+		//
+		ok bool
+	}
+	tagsTests := []tagsTest{
+		{`db:"name"`, true},
+		{`db:"name" xml:"name,attr"`, true},
+		{`db:""`, true},
+		{`json:"-"`, true},
+		{`db:"name"  xml:"x"`, true},
+		{`db:name`, false},          // no quotes
+		{`db:"name"xml:"x"`, false}, // no space
+		{`db: "name"`, false},       // space after colon
+		{`db:"name" `, false},       // trailing space
+		{"db:\"a`b\"", false},       // backquote
+		{`1db:"name"`, false},       // key with leading digit
+		{`db:"na"me"`, false},       // quote in value
+		{``, false},
+	}
+
+	for i, v := range tagsTests {
+		ok := validTags.MatchString(v.tags) // <------- ACTION
+		if ok != v.ok {
+			t.Error("#"+fmt.Sprintf("%d", i+1), v.tags, "expected", v.ok, "got", ok)
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+}
+
+// TestSetTag tests struct tags and JSON names of attributes.
+func TestSetTag(t *testing.T) {
+
+	type tagTest struct {
+		att      Att
+		jsonCase string
+		//
+		ok    bool
+		tag   string
+		field string
+		omit  bool
+	}
+	tagTests := []tagTest{
+		{Att{Name: "UsID"}, "", true, "`json:\"usID\"`", "usID", false},
+		{Att{Name: "UsID"}, "snake", true, "`json:\"us_id\"`", "us_id", false},
+		{Att{Name: "UsID", Nullable: true}, "snake", true, "`json:\"us_id,omitempty\"`", "us_id", true},
+		{Att{Name: "UsID"}, "none", true, "", "UsID", false},
+		{Att{Name: "UsID", JSONName: "id"}, "snake", true, "`json:\"id\"`", "id", false},
+		{Att{Name: "UsID", Tags: `db:"us"`}, "camel", true, "`json:\"usID\" db:\"us\"`", "usID", false},
+		{Att{Name: "UsID", Tags: `json:"-"`}, "camel", true, "`json:\"-\"`", "", false},
+		{Att{Name: "UsID", Tags: `json:",omitempty"`}, "none", true, "`json:\",omitempty\"`", "UsID", true},
+		{Att{Name: "UsID"}, "kebab", false, "", "", false},           // unknown JSONCase
+		{Att{Name: "UsID", Tags: `db:us`}, "", false, "", "", false}, // malformed Tags
+	}
+
+	for i, v := range tagTests {
+		att := v.att
+		ok := setTag(&att, v.jsonCase) // <------- ACTION
+		switch {
+		case ok != v.ok:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.ok, "got", ok)
+		case !ok:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, false expected")
+		case att.Tag != v.tag || att.JSONField != v.field || att.JSONOmit != v.omit:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.tag, v.field, v.omit,
+				"got", att.Tag, att.JSONField, att.JSONOmit)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestStdAtts tests JSON names of livedb.Std in the generated code.
+func TestStdAtts(t *testing.T) {
+
+	type stdTest struct {
+		jsonCase string
+		//
+		dto       bool // StdAtts set
+		createdBy string
+		endedBy   string
+	}
+	stdTests := []stdTest{
+		{"", false, "createdBy", "endedBy"},
+		{"camel", false, "createdBy", "endedBy"},
+		{"snake", true, "created_by", "ended_by"},
+		{"none", false, "createdBy", "endedBy"},
+	}
+
+	for i, v := range stdTests {
+		vals := Values{JSONCase: v.jsonCase}
+		setStdAtts(&vals) // <------- ACTION
+		switch {
+		case (vals.StdAtts != nil) != v.dto:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected StdAtts", v.dto, "got", vals.StdAtts != nil)
+		case vals.StdJSON("CreatedBy") != v.createdBy || vals.StdJSON("EndedBy") != v.endedBy:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.createdBy, v.endedBy,
+				"got", vals.StdJSON("CreatedBy"), vals.StdJSON("EndedBy"))
+		case v.dto && vals.StdAtts[6].Tag != "`json:\"ended,omitempty\"`":
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected Ended omitempty, got", vals.StdAtts[6].Tag)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
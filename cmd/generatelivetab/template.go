@@ -9,7 +9,7 @@ const tmpl = `// Copyright {{.Copyright}}. All rights reserved.
 // that can be found in the LICENSE file.
 
 // THIS FILE HAS BEEN GENERATED BY {{.Generator}} using {{.Input}}.
-// ON {{.Generated}}. DO NOT EDIT.
+{{if .Generated}}// ON {{.Generated}}. DO NOT EDIT.{{else}}// DO NOT EDIT.{{end}}
 // MANUAL CHANGES WILL DISAPPEAR AFTER NEXT RUN OF {{.Generator}}.
 
 /*
@@ -18,55 +18,94 @@ const tmpl = `// Copyright {{.Copyright}}. All rights reserved.
 
 package {{.Package}}
 
-import (
-	"database/sql"
-	"fmt"
+import ({{if .StdAtts}}
+	"bytes"{{end}}
+	"database/sql"{{if .StdAtts}}
+	"encoding/json"{{end}}
+	"fmt"{{if .UsesRegexp}}
+	"regexp"{{end}}{{if .HasRules}}
+	"strings"{{end}}{{if .UsesTime}}
+	"time"{{end}}{{if .UsesUTF8}}
+	"unicode/utf8"{{end}}
 
 	"github.com/hwheinzen/livedb"
 
 	. "github.com/hwheinzen/stringl10n/mistake"
 )
 
-const {{.LcAcronym}}Tab = "{{.DbName}}"
+// {{exp .LcAcronym}}Tab is the name of the database table.
+const {{exp .LcAcronym}}Tab = "{{.DbName}}"
 
-var {{.LcAcronym}}Atts = []string{ // specific fields for {{.LcAcronym}}Tab {{range .Atts}}
+// {{exp .LcAcronym}}Atts are the names of the specific attributes of {{exp .LcAcronym}}Tab.
+var {{exp .LcAcronym}}Atts = []string{ {{range .Atts}}
 	"{{.DbName}}",{{end}}
 }
 
-var {{.LcAcronym}}Defs = []string{ {{range .Atts}}
+// {{exp .LcAcronym}}Defs are the definitions of the specific attributes of {{exp .LcAcronym}}Tab.
+var {{exp .LcAcronym}}Defs = []string{ {{range .Atts}}
 	"{{.DbName}} {{.CreateClause}}",{{end}}
 }
 
-// {{.LcAcronym}} enthält alle spezifischen Attribute von {{.DbName}}.
-type {{.LcAcronym}} struct { {{range .Atts}}
-	{{.Name}} {{if .IsNumType}}int{{/*
-		*/}}{{else}}string{{end}}{{end}}
+// {{exp .LcAcronym}} enthält alle spezifischen Attribute von {{.DbName}}.
+type {{exp .LcAcronym}} struct { {{range .Atts}}
+	{{.Name}} {{.GoType}}{{if .Tag}} {{.Tag}}{{end}}{{end}}
 }
 
-// {{.LcName}} contains all attributes of table {{.DbName}}.
-type {{.LcName}} struct {
+// {{exp .LcName}} contains all attributes of table {{.DbName}}.
+type {{exp .LcName}} struct {
 	livedb.Std // embedded
-	{{.LcAcronym}}         // embedded
+	{{exp .LcAcronym}}         // embedded
+}
+{{if .StdAtts}}
+// {{.LcAcronym}}Std is livedb.Std with JSON names in {{.JSONCase}} case.
+type {{.LcAcronym}}Std struct { {{range .StdAtts}}
+	{{.Name}} {{.GoType}} {{.Tag}}{{end}}
 }
 
-func {{.LcAcronym}}Scan(rows *sql.Rows) (livedb.Record, error) {
-	fnc := "{{.LcAcronym}}Scan"
+// MarshalJSON encodes x with the JSON names of {{.LcAcronym}}Std.
+func (x {{exp .LcName}}) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		{{.LcAcronym}}Std
+		{{exp .LcAcronym}}
+	}{ {{.LcAcronym}}Std(x.Std), x.{{exp .LcAcronym}} })
+}
+
+// UnmarshalJSON decodes b with the JSON names of {{.LcAcronym}}Std;
+// unknown fields are invalid.
+func (x *{{exp .LcName}}) UnmarshalJSON(b []byte) error {
+	v := struct {
+		{{.LcAcronym}}Std
+		{{exp .LcAcronym}}
+	}{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&v)
+	if err != nil {
+		return err
+	}
+	x.Std, x.{{exp .LcAcronym}} = livedb.Std(v.{{.LcAcronym}}Std), v.{{exp .LcAcronym}}
+	return nil
+}
+{{end}}
+// {{exp .LcAcronym}}Scan scans a row of {{exp .LcAcronym}}Tab (livedb.ScanFunc).
+func {{exp .LcAcronym}}Scan(rows *sql.Rows) (livedb.Record, error) {
+	fnc := "{{exp .LcAcronym}}Scan"
 
 	std := livedb.Std{}
 	nullStr1 := sql.NullString{} // Until
 	nullStr2 := sql.NullString{} // Ended
 	nullStr3 := sql.NullString{} // EndedBy
 
-	x := {{.LcAcronym}}{}
-	{{range $i, $att := .Atts}}
-	nullVar{{$i}} := sql.Null{{if $att.IsNumType}}Int64{{else}}String{{end}}{}{{end}}
+	x := {{exp .LcAcronym}}{}
+	{{range $i, $att := .Atts}}{{if $att.NullType}}
+	nullVar{{$i}} := {{$att.NullType}}{}{{end}}{{end}}
 
 	err := rows.Scan(
 		&(std.ID), &(std.Begin), &(nullStr1),
 		&(std.Pkey),
 		&(std.Created), &(std.CreatedBy),
 		&(nullStr2), &(nullStr3),{{range $i, $p := .Atts}}
-		&(nullVar{{$i}}),{{end}}
+		{{if $p.NullType}}&(nullVar{{$i}}){{else}}&(x.{{$p.Name}}){{end}},{{end}}
 	)
 	if err != nil {
 		e := Err{Fix: "{{.UCPackage}}:error scanning row"}
@@ -81,33 +120,84 @@ func {{.LcAcronym}}Scan(rows *sql.Rows) (livedb.Record, error) {
 	}
 	if nullStr3.Valid {
 		std.EndedBy = nullStr3.String
-	} {{range $i, $att := .Atts}}
-	if nullVar{{$i}}.Valid {
-		x.{{$att.Name}} = {{if ne $att.Type ""}}{{$att.Type}}({{else}}{{if $att.IsNumType}}int({{end}}{{end}}{{/*
-		*/}}nullVar{{$i}}.{{if $att.IsNumType}}Int64{{else}}String{{end}}{{if or (ne $att.Type "") ($att.IsNumType)}}){{end}}
-	}{{end}}
+	} {{range $i, $att := .Atts}}{{if $att.NullType}}
+	if nullVar{{$i}}.Valid { {{if $att.Nullable}}
+		v := {{if $att.Convert}}{{$att.Convert}}({{end}}nullVar{{$i}}.{{$att.NullField}}{{if $att.Convert}}){{end}}
+		x.{{$att.Name}} = &v{{else}}
+		x.{{$att.Name}} = {{if $att.Convert}}{{$att.Convert}}({{end}}nullVar{{$i}}.{{$att.NullField}}{{if $att.Convert}}){{end}}{{end}}
+	}{{end}}{{end}}
 
 	return livedb.Record{Std:std, Idv:x}, nil
 }
 
-// {{.LcAcronym}}Vals returns string representations of the fields of {{.LcName}}.
-func {{.LcAcronym}}Vals(in interface{}) []string {
-	//fnc := "{{.LcAcronym}}Vals"
+// {{exp .LcAcronym}}Vals returns the values of the fields of {{exp .LcName}}.
+func {{exp .LcAcronym}}Vals(in interface{}) []interface{} {
+	//fnc := "{{exp .LcAcronym}}Vals"
 
-	x := in.({{.LcAcronym}})
+	x := in.({{exp .LcAcronym}})
 	_ = x // use it in case of "empty" table
 
-	vals := make([]string, len({{.LcAcronym}}Atts)) {{$lcacr := .LcAcronym}} {{range $i, $att := .Atts}}
-	vals[{{$i}}] = {{if $att.IsNumType}}fmt.Sprintf("%0.d", x.{{$att.Name}}) // "" indicates NULL{{else}}{{if ne $att.Type ""}}fmt.Sprint(x.{{$att.Name}}){{else}}x.{{$att.Name}}{{end}}{{end}}{{end}}
+	vals := make([]interface{}, len({{exp .LcAcronym}}Atts)) // nil indicates NULL{{range $i, $att := .Atts}}{{if $att.ValCond}}
+	if {{$att.ValCond}} {
+		vals[{{$i}}] = {{$att.ValExpr}}
+	}{{else}}
+	vals[{{$i}}] = {{$att.ValExpr}}{{end}}{{end}}
 
 	return vals
 }
+{{range .Atts}}{{if .PatternVar}}
+// {{.PatternVar}} is the Pattern of attribute {{.Name}}.
+var {{.PatternVar}} = regexp.MustCompile({{printf "%q" .Pattern}})
+{{end}}{{end}}
+{{if .HasRules}}// {{exp .LcAcronym}}Fails are the violations of validation rules
+// found by {{exp "validate"}}{{.UcAcronym}}, one Err per rule.
+type {{exp .LcAcronym}}Fails []Err
+
+// Error returns the messages of all violations (untranslated).
+func (fs {{exp .LcAcronym}}Fails) Error() string {
+	msgs := make([]string, len(fs))
+	for i, f := range fs {
+		msg, err := livedb.L10nReplace(f.Fix, f.Var)
+		if err != nil {
+			msg = f.Fix
+		}
+		msgs[i] = msg
+	}
+	return strings.Join(msgs, ", ")
+}
+
+{{end}}// {{exp "validate"}}{{.UcAcronym}} checks x against the validation rules
+// of the attributes and reports all violations in one error ({{exp .LcAcronym}}Fails).
+func {{exp "validate"}}{{.UcAcronym}}(x {{exp .LcAcronym}}) error {
+{{if .HasRules}}	fnc := "{{exp "validate"}}{{.UcAcronym}}"
+
+	var fails {{exp .LcAcronym}}Fails{{range .Atts}}{{range .Rules}}
+	if {{.Cond}} {
+		fails = append(fails, Err{
+			Fix: {{printf "%q" (print $.UCPackage ":" .Fix)}},
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{ {{range .Var}}
+				{"{{.Name}}", {{.Value}}},{{end}}
+			},
+		})
+	}{{end}}{{end}}
 
-func create{{.UcAcronym}}(tx *sql.Tx) error {
-	fnc := "create{{.UcAcronym}}"
+	if len(fails) > 0 {
+		return fmt.Errorf(fnc+":%w", livedb.MarkErr(livedb.ErrInvalid, fails))
+	}
+{{else}}	_ = x // no validation rules
+{{end}}
+	return nil
+}
 
-	t := livedb.Table{Name: {{.LcAcronym}}Tab, Defs: {{.LcAcronym}}Defs}
-	err := t.Create(tx)
+// {{exp "create"}}{{.UcAcronym}} creates {{exp .LcAcronym}}Tab unless it exists.
+func {{exp "create"}}{{.UcAcronym}}(q livedb.Querier) error {
+	fnc := "{{exp "create"}}{{.UcAcronym}}"
+
+	t := livedb.Table{Name: {{exp .LcAcronym}}Tab, Defs: {{exp .LcAcronym}}Defs, Origin: "{{.Origin}}"}
+	err := t.Create(q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -115,17 +205,36 @@ func create{{.UcAcronym}}(tx *sql.Tx) error {
 	return nil
 }
 
-// empty{{.UcAcronym}} returns a {{.LcName}} struct with a newly reserved ID.
-func empty{{.UcAcronym}}(creator string, tx *sql.Tx) (*{{.LcName}}, error) {
-	fnc := "empty{{.UcAcronym}}"
+{{if .Version}}// {{exp .LcAcronym}}Version is the schema version of {{exp .LcAcronym}}Tab.
+const {{exp .LcAcronym}}Version = {{.Version}}
+
+// {{exp "migrate"}}{{.UcAcronym}} brings {{exp .LcAcronym}}Tab in line with {{exp .LcAcronym}}Defs
+// and records {{exp .LcAcronym}}Version. With dryRun the needed statements
+// are returned only. Attributes not in {{exp .LcAcronym}}Defs are dropped
+// with dropMissing only, otherwise they are an error.
+func {{exp "migrate"}}{{.UcAcronym}}(creator string, dryRun, dropMissing bool, q livedb.Querier) ([]string, error) {
+	fnc := "{{exp "migrate"}}{{.UcAcronym}}"
+
+	t := livedb.Table{Name: {{exp .LcAcronym}}Tab, Defs: {{exp .LcAcronym}}Defs, Atts: {{exp .LcAcronym}}Atts, Origin: "{{.Origin}}"}
+	stmts, err := t.Migrate({{exp .LcAcronym}}Version, creator, dryRun, dropMissing, q)
+	if err != nil {
+		return stmts, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return stmts, nil
+}
+
+{{end}}// {{exp "empty"}}{{.UcAcronym}} returns a {{exp .LcName}} struct with a newly reserved ID.
+func {{exp "empty"}}{{.UcAcronym}}(creator string, q livedb.Querier) (*{{exp .LcName}}, error) {
+	fnc := "{{exp "empty"}}{{.UcAcronym}}"
 
-	t := livedb.Table{Name: {{.LcAcronym}}Tab}
-	id, err := t.NewID(creator, tx)
+	t := livedb.Table{Name: {{exp .LcAcronym}}Tab}
+	id, err := t.NewID(creator, q)
 	if err != nil {
 		return nil, fmt.Errorf(fnc+":%w", err)
 	}
 
-	var xp = &{{.LcName}}{
+	var xp = &{{exp .LcName}}{
 		Std: livedb.Std{
 			ID:   id,
 			Created: creator,
@@ -135,31 +244,36 @@ func empty{{.UcAcronym}}(creator string, tx *sql.Tx) (*{{.LcName}}, error) {
 	return xp, nil
 }
 
-// start{{.UcAcronym}} inserts the first row for a new ID,
+// {{exp "start"}}{{.UcAcronym}} inserts the first row for a new ID,
 // and returns its primary key.
-func start{{.UcAcronym}}(xp *{{.LcName}}, ts, creator string, tx *sql.Tx) (int, error) {
-	fnc := "start{{.UcAcronym}}"
+func {{exp "start"}}{{.UcAcronym}}(xp *{{exp .LcName}}, ts, creator string, q livedb.Querier) (int, error) {
+	fnc := "{{exp "start"}}{{.UcAcronym}}"
 
-	if xp == nil { // *{{.LcName}} needed
+	if xp == nil { // *{{exp .LcName}} needed
 		err := Err{
 			Fix: "{{.UCPackage}}:{{.NameTemplate}} missing",
 			Var: []struct {
 				Name  string
 				Value interface{}
 			}{
-				{"Name", "{{.LcName}}"},
+				{"Name", "{{exp .LcName}}"},
 			},
 		}
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
+	err := {{exp "validate"}}{{.UcAcronym}}(xp.{{exp .LcAcronym}})
+	if err != nil {
+		return 0, fmt.Errorf(fnc+":%w", err)
+	}
+
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
-		New:  livedb.Record{Idv: xp.{{.LcAcronym}}},
-		Atts: {{.LcAcronym}}Atts,
-		Vals: {{.LcAcronym}}Vals,
+		Name: {{exp .LcAcronym}}Tab,
+		New:  livedb.Record{Idv: xp.{{exp .LcAcronym}}},
+		Atts: {{exp .LcAcronym}}Atts,
+		Vals: {{exp .LcAcronym}}Vals,
 	}
-	key, err := tab.Start(xp.ID, ts, creator, tx) // insert new row for new ID
+	key, err := tab.Start(xp.ID, ts, creator, q) // insert new row for new ID
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -167,18 +281,18 @@ func start{{.UcAcronym}}(xp *{{.LcName}}, ts, creator string, tx *sql.Tx) (int,
 	return key, nil
 }
 
-// {{.LcAcronym}}Pair contains old and new {{.LcName}}.
-type {{.LcAcronym}}Pair struct {
-	Old {{.LcName}}
-	New {{.LcName}}
+// {{exp .LcAcronym}}Pair contains old and new {{exp .LcName}}.
+type {{exp .LcAcronym}}Pair struct {
+	Old {{exp .LcName}} {{.OldTag}}
+	New {{exp .LcName}} {{.NewTag}}
 }
 
-// change{{.UcAcronym}} makes a change to {{.LcAcronym}}Tab using livedb.Change,
+// {{exp "change"}}{{.UcAcronym}} makes a change to {{exp .LcAcronym}}Tab using livedb.Change,
 // and returns the primary key of the inserted row.
-func change{{.UcAcronym}}(pair *{{.LcAcronym}}Pair, ts, creator string, tx *sql.Tx) (int, error) {
-	fnc := "change{{.UcAcronym}}"
+func {{exp "change"}}{{.UcAcronym}}(pair *{{exp .LcAcronym}}Pair, ts, creator string, q livedb.Querier) (int, error) {
+	fnc := "{{exp "change"}}{{.UcAcronym}}"
 
-	if pair == nil { // *{{.LcAcronym}}Pair needed
+	if pair == nil { // *{{exp .LcAcronym}}Pair needed
 		err := Err{
 			Fix: "{{.UCPackage}}:{{.NameTemplate}} missing",
 			Var: []struct {
@@ -194,20 +308,25 @@ func change{{.UcAcronym}}(pair *{{.LcAcronym}}Pair, ts, creator string, tx *sql.
 	old := &pair.Old
 	new := &pair.New
 
+	err := {{exp "validate"}}{{.UcAcronym}}(new.{{exp .LcAcronym}})
+	if err != nil {
+		return 0, fmt.Errorf(fnc+":%w", err)
+	}
+
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
+		Name: {{exp .LcAcronym}}Tab,
 		Old:  livedb.Record{
 			Std: old.Std,
-			Idv: old.{{.LcAcronym}},
+			Idv: old.{{exp .LcAcronym}},
 		},
 		New:  livedb.Record{
-			Idv: new.{{.LcAcronym}},
+			Idv: new.{{exp .LcAcronym}},
 		},
-		Atts: {{.LcAcronym}}Atts,
-		Vals: {{.LcAcronym}}Vals,
-		Scan: {{.LcAcronym}}Scan,
+		Atts: {{exp .LcAcronym}}Atts,
+		Vals: {{exp .LcAcronym}}Vals,
+		Scan: {{exp .LcAcronym}}Scan,
 	}
-	key, err := tab.Change(ts, creator, tx) // regular change to row of ID
+	key, err := tab.Change(ts, creator, q) // regular change to row of ID
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -215,34 +334,34 @@ func change{{.UcAcronym}}(pair *{{.LcAcronym}}Pair, ts, creator string, tx *sql.
 	return key, nil
 }
 
-// terminate{{.UcAcronym}} sets the Until timestamp of the given row, eventually deletes followers,
+// {{exp "terminate"}}{{.UcAcronym}} sets the Until timestamp of the given row, eventually deletes followers,
 // and returns its primary key.
-func terminate{{.UcAcronym}}(xp *{{.LcName}}, ts, creator string, tx *sql.Tx) (int, error) {
-	fnc := "terminate{{.UcAcronym}}"
+func {{exp "terminate"}}{{.UcAcronym}}(xp *{{exp .LcName}}, ts, creator string, q livedb.Querier) (int, error) {
+	fnc := "{{exp "terminate"}}{{.UcAcronym}}"
 
-	if xp == nil { // *{{.LcName}} needed
+	if xp == nil { // *{{exp .LcName}} needed
 		err := Err{
 			Fix: "{{.UCPackage}}:{{.NameTemplate}} missing",
 			Var: []struct {
 				Name  string
 				Value interface{}
 			}{
-				{"Name", "{{.LcName}}"},
+				{"Name", "{{exp .LcName}}"},
 			},
 		}
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
+		Name: {{exp .LcAcronym}}Tab,
 		Old:  livedb.Record{
 			Std: xp.Std,
-			Idv: xp.{{.LcAcronym}},
+			Idv: xp.{{exp .LcAcronym}},
 		},
-		Atts: {{.LcAcronym}}Atts,
-		Scan: {{.LcAcronym}}Scan,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
 	}
-	key, err := tab.Terminate(ts, creator, tx) // terminate row of ID
+	key, err := tab.Terminate(ts, creator, q) // terminate row of ID
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -250,33 +369,33 @@ func terminate{{.UcAcronym}}(xp *{{.LcName}}, ts, creator string, tx *sql.Tx) (i
 	return key, nil
 }
 
-// moveBegin{{.UcAcronym}} begins a given {{.LcName}} row with Begin = ts.
-func moveBegin{{.UcAcronym}}(xp *{{.LcName}}, ts, creator string, tx *sql.Tx) (int, error) {
-	fnc := "moveBegin{{.UcAcronym}}"
+// {{exp "moveBegin"}}{{.UcAcronym}} begins a given {{exp .LcName}} row with Begin = ts.
+func {{exp "moveBegin"}}{{.UcAcronym}}(xp *{{exp .LcName}}, ts, creator string, q livedb.Querier) (int, error) {
+	fnc := "{{exp "moveBegin"}}{{.UcAcronym}}"
 
-	if xp == nil { // *{{.LcName}} needed
+	if xp == nil { // *{{exp .LcName}} needed
 		err := Err{
 			Fix: "{{.UCPackage}}:{{.NameTemplate}} missing",
 			Var: []struct {
 				Name  string
 				Value interface{}
 			}{
-				{"Name", "{{.LcName}}"},
+				{"Name", "{{exp .LcName}}"},
 			},
 		}
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
+		Name: {{exp .LcAcronym}}Tab,
 		Old:  livedb.Record{
 			Std: xp.Std,
-			Idv: xp.{{.LcAcronym}},
+			Idv: xp.{{exp .LcAcronym}},
 		},
-		Atts: {{.LcAcronym}}Atts,
-		Scan: {{.LcAcronym}}Scan,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
 	}
-	key, err := tab.MoveBegin(ts, creator, tx) // terminate row of ID
+	key, err := tab.MoveBegin(ts, creator, q) // terminate row of ID
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -284,33 +403,33 @@ func moveBegin{{.UcAcronym}}(xp *{{.LcName}}, ts, creator string, tx *sql.Tx) (i
 	return key, nil
 }
 
-// moveUntil{{.UcAcronym}} ends a given {{.LcName}} row with Until = ts.
-func moveUntil{{.UcAcronym}}(xp *{{.LcName}}, ts, creator string, tx *sql.Tx) (int, error) {
-	fnc := "moveUntil{{.UcAcronym}}"
+// {{exp "moveUntil"}}{{.UcAcronym}} ends a given {{exp .LcName}} row with Until = ts.
+func {{exp "moveUntil"}}{{.UcAcronym}}(xp *{{exp .LcName}}, ts, creator string, q livedb.Querier) (int, error) {
+	fnc := "{{exp "moveUntil"}}{{.UcAcronym}}"
 
-	if xp == nil { // *{{.LcName}} needed
+	if xp == nil { // *{{exp .LcName}} needed
 		err := Err{
 			Fix: "{{.UCPackage}}:{{.NameTemplate}} missing",
 			Var: []struct {
 				Name  string
 				Value interface{}
 			}{
-				{"Name", "{{.LcName}}"},
+				{"Name", "{{exp .LcName}}"},
 			},
 		}
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
+		Name: {{exp .LcAcronym}}Tab,
 		Old:  livedb.Record{
 			Std: xp.Std,
-			Idv: xp.{{.LcAcronym}},
+			Idv: xp.{{exp .LcAcronym}},
 		},
-		Atts: {{.LcAcronym}}Atts,
-		Scan: {{.LcAcronym}}Scan,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
 	}
-	key, err := tab.MoveUntil(ts, creator, tx) // terminate row of ID
+	key, err := tab.MoveUntil(ts, creator, q) // terminate row of ID
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -318,25 +437,27 @@ func moveUntil{{.UcAcronym}}(xp *{{.LcName}}, ts, creator string, tx *sql.Tx) (i
 	return key, nil
 }
 
-func recsTo{{.UcAcronym}}(recs []livedb.Record) (xs []{{.LcName}}) {
-	var x {{.LcName}}
+// recsTo{{.UcAcronym}} converts livedb records into {{exp .LcName}}s.
+func recsTo{{.UcAcronym}}(recs []livedb.Record) (xs []{{exp .LcName}}) {
+	var x {{exp .LcName}}
 	for _, v := range recs {
-		x = {{.LcName}}{Std: v.Std, {{.LcAcronym}}: v.Idv.({{.LcAcronym}})}
+		x = {{exp .LcName}}{Std: v.Std, {{exp .LcAcronym}}: v.Idv.({{exp .LcAcronym}})}
 		xs = append(xs, x)
 	}
 	return xs
 }
 
-func {{.LcAcronym}}ByKey(key int, tx *sql.Tx) ([]{{.LcName}}, error) {
-	fnc := "{{.LcAcronym}}ByKey"
+// {{exp .LcAcronym}}ByKey returns the {{exp .LcName}} with primary key key.
+func {{exp .LcAcronym}}ByKey(key int, q livedb.Querier) ([]{{exp .LcName}}, error) {
+	fnc := "{{exp .LcAcronym}}ByKey"
 
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
-		Atts: {{.LcAcronym}}Atts,
-		Scan: {{.LcAcronym}}Scan,
+		Name: {{exp .LcAcronym}}Tab,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
 	}
 
-	recs, err := tab.ByKey(key, tx)
+	recs, err := tab.ByKey(key, q)
 	if err != nil {
 		return nil, fmt.Errorf(fnc+":%w", err)
 	}
@@ -344,16 +465,17 @@ func {{.LcAcronym}}ByKey(key int, tx *sql.Tx) ([]{{.LcName}}, error) {
 	return recsTo{{.UcAcronym}}(recs), nil
 }
 
-func {{.LcAcronym}}sByTs(ts string, tx *sql.Tx) ([]{{.LcName}}, error) {
-	fnc := "{{.LcAcronym}}sByTs"
+// {{exp .LcAcronym}}sByTs returns all {{exp .LcName}}s valid at ts.
+func {{exp .LcAcronym}}sByTs(ts string, q livedb.Querier, opts ...func(*livedb.Table)) ([]{{exp .LcName}}, error) {
+	fnc := "{{exp .LcAcronym}}sByTs"
 
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
-		Atts: {{.LcAcronym}}Atts,
-		Scan: {{.LcAcronym}}Scan,
+		Name: {{exp .LcAcronym}}Tab,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
 	}
 
-	recs, err := tab.ByTs(ts, tx)
+	recs, err := tab.ByTs(ts, q, opts...)
 	if err != nil {
 		return nil, fmt.Errorf(fnc+":%w", err)
 	}
@@ -361,16 +483,94 @@ func {{.LcAcronym}}sByTs(ts string, tx *sql.Tx) ([]{{.LcName}}, error) {
 	return recsTo{{.UcAcronym}}(recs), nil
 }
 
-func {{.LcAcronym}}ByIDTs(id int, ts string, tx *sql.Tx) ([]{{.LcName}}, error) {
-	fnc := "{{.LcAcronym}}ByIDTs"
+// {{exp .LcAcronym}}sWhere returns all {{exp .LcName}}s valid at ts satisfying filter f,
+// e.g. livedb.Lt(column, value) with a column of {{exp .LcAcronym}}Atts.
+func {{exp .LcAcronym}}sWhere(ts string, f livedb.Filter, q livedb.Querier, opts ...func(*livedb.Table)) ([]{{exp .LcName}}, error) {
+	fnc := "{{exp .LcAcronym}}sWhere"
+
+	tab := livedb.Table {
+		Name: {{exp .LcAcronym}}Tab,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
+	}
+
+	opts = append([]func(*livedb.Table){livedb.WithFilter(f)}, opts...)
+	recs, err := tab.ByTs(ts, q, opts...)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return recsTo{{.UcAcronym}}(recs), nil
+}
+
+// {{exp .LcAcronym}}sByPeriod returns all {{exp .LcName}}s valid at any time within [from; until[.
+func {{exp .LcAcronym}}sByPeriod(from, until string, q livedb.Querier, opts ...func(*livedb.Table)) ([]{{exp .LcName}}, error) {
+	fnc := "{{exp .LcAcronym}}sByPeriod"
+
+	tab := livedb.Table {
+		Name: {{exp .LcAcronym}}Tab,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
+	}
+
+	recs, err := tab.ByPeriod(from, until, q, opts...)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return recsTo{{.UcAcronym}}(recs), nil
+}
+
+// {{exp .LcAcronym}}History returns all {{exp .LcName}}s with ID id ordered by Begin.
+func {{exp .LcAcronym}}History(id int, q livedb.Querier, opts ...func(*livedb.Table)) ([]{{exp .LcName}}, error) {
+	fnc := "{{exp .LcAcronym}}History"
+
+	tab := livedb.Table {
+		Name: {{exp .LcAcronym}}Tab,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
+	}
+
+	recs, err := tab.History(id, q, opts...)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return recsTo{{.UcAcronym}}(recs), nil
+}
+
+// {{exp "iterate"}}{{.UcAcronym}}sByTs calls fn for each {{exp .LcName}} valid at ts
+// without loading all of them into memory.
+func {{exp "iterate"}}{{.UcAcronym}}sByTs(ts string, q livedb.Querier, fn func({{exp .LcName}}) error, opts ...func(*livedb.Table)) error {
+	fnc := "{{exp "iterate"}}{{.UcAcronym}}sByTs"
+
+	tab := livedb.Table {
+		Name: {{exp .LcAcronym}}Tab,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
+	}
+
+	err := tab.IterateByTs(ts, q, func(rec livedb.Record) error {
+		return fn({{exp .LcName}}{Std: rec.Std, {{exp .LcAcronym}}: rec.Idv.({{exp .LcAcronym}})})
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+// {{exp .LcAcronym}}ByIDTs returns the {{exp .LcName}} with ID id valid at ts.
+func {{exp .LcAcronym}}ByIDTs(id int, ts string, q livedb.Querier, opts ...func(*livedb.Table)) ([]{{exp .LcName}}, error) {
+	fnc := "{{exp .LcAcronym}}ByIDTs"
 
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
-		Atts: {{.LcAcronym}}Atts,
-		Scan: {{.LcAcronym}}Scan,
+		Name: {{exp .LcAcronym}}Tab,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
 	}
 
-	recs, err := tab.ByIDTs(id, ts, tx)
+	recs, err := tab.ByIDTs(id, ts, q, opts...)
 	if err != nil {
 		return nil, fmt.Errorf(fnc+":%w", err)
 	}
@@ -378,16 +578,17 @@ func {{.LcAcronym}}ByIDTs(id int, ts string, tx *sql.Tx) ([]{{.LcName}}, error)
 	return recsTo{{.UcAcronym}}(recs), nil
 }
 
-func {{.LcAcronym}}ByIDBegin(id int, begin string, tx *sql.Tx) ([]{{.LcName}}, error) {
-	fnc := "{{.LcAcronym}}ByIDBegin"
+// {{exp .LcAcronym}}ByIDBegin returns the {{exp .LcName}} with ID id beginning at begin.
+func {{exp .LcAcronym}}ByIDBegin(id int, begin string, q livedb.Querier) ([]{{exp .LcName}}, error) {
+	fnc := "{{exp .LcAcronym}}ByIDBegin"
 
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
-		Atts: {{.LcAcronym}}Atts,
-		Scan: {{.LcAcronym}}Scan,
+		Name: {{exp .LcAcronym}}Tab,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
 	}
 
-	recs, err := tab.ByIDBegin(id, begin, tx)
+	recs, err := tab.ByIDBegin(id, begin, q)
 	if err != nil {
 		return nil, fmt.Errorf(fnc+":%w", err)
 	}
@@ -395,26 +596,28 @@ func {{.LcAcronym}}ByIDBegin(id int, begin string, tx *sql.Tx) ([]{{.LcName}}, e
 	return recsTo{{.UcAcronym}}(recs), nil
 }
 
-func {{.LcAcronym}}ByIDUntil(id int, until string, tx *sql.Tx) ([]{{.LcName}}, error) {
-	fnc := "{{.LcAcronym}}ByIDUntil"
+// {{exp .LcAcronym}}ByIDUntil returns the {{exp .LcName}} with ID id ending at until.
+func {{exp .LcAcronym}}ByIDUntil(id int, until string, q livedb.Querier) ([]{{exp .LcName}}, error) {
+	fnc := "{{exp .LcAcronym}}ByIDUntil"
 
 	tab := livedb.Table {
-		Name: {{.LcAcronym}}Tab,
-		Atts: {{.LcAcronym}}Atts,
-		Scan: {{.LcAcronym}}Scan,
+		Name: {{exp .LcAcronym}}Tab,
+		Atts: {{exp .LcAcronym}}Atts,
+		Scan: {{exp .LcAcronym}}Scan,
 	}
 
-	recs, err := tab.ByIDUntil(id, until, tx)
+	recs, err := tab.ByIDUntil(id, until, q)
 	if err != nil {
 		return nil, fmt.Errorf(fnc+":%w", err)
 	}
 
 	return recsTo{{.UcAcronym}}(recs), nil
 }
-{{$Name := .LcName}}{{$LcAcronym := .LcAcronym}}{{$UcAcronym := .UcAcronym}}{{range .Atts}}{{if .ReadBy}}
+{{$Name := exp .LcName}}{{$LcAcronym := exp .LcAcronym}}{{$UcAcronym := .UcAcronym}}{{range .Atts}}{{if .ReadBy}}
 
-func {{$LcAcronym}}sBy{{.Name}}Ts({{.LcName}} {{if .IsNumType}}int{{else}}string{{end}}, ts string, tx *sql.Tx) ([]{{$Name}}, error) {
-	fnc := "{{$LcAcronym}}sByIDTs"
+// {{$LcAcronym}}sBy{{.Name}}Ts returns all {{$Name}}s valid at ts with the given {{.Name}}.
+func {{$LcAcronym}}sBy{{.Name}}Ts({{.LcName}} {{.ArgType}}, ts string, q livedb.Querier, opts ...func(*livedb.Table)) ([]{{$Name}}, error) {
+	fnc := "{{$LcAcronym}}sBy{{.Name}}Ts"
 
 	tab := livedb.Table {
 		Name: {{$LcAcronym}}Tab,
@@ -422,16 +625,773 @@ func {{$LcAcronym}}sBy{{.Name}}Ts({{.LcName}} {{if .IsNumType}}int{{else}}string
 		Scan: {{$LcAcronym}}Scan,
 	}
 
-	nvs := []livedb.NameValue{ {Name: "{{.Name}}", Value: {{.LcName}}} }
-	recs, err := tab.ByTsAndXs(ts, nvs, tx)
+	nvs := []livedb.NameValue{ {Name: "{{.DbName}}", Value: {{.ArgExpr}}} }
+	recs, err := tab.ByTsAndXs(ts, nvs, q, opts...)
 	if err != nil {
 		return nil, fmt.Errorf(fnc+":%w", err)
 	}
 
 	return recsTo{{$UcAcronym}}(recs), nil
+}
+
+// {{exp "iterate"}}{{$UcAcronym}}sBy{{.Name}}Ts calls fn for each {{$Name}} valid at ts
+// with the given {{.Name}} without loading all of them into memory.
+func {{exp "iterate"}}{{$UcAcronym}}sBy{{.Name}}Ts({{.LcName}} {{.ArgType}}, ts string, q livedb.Querier, fn func({{$Name}}) error, opts ...func(*livedb.Table)) error {
+	fnc := "{{exp "iterate"}}{{$UcAcronym}}sBy{{.Name}}Ts"
+
+	tab := livedb.Table {
+		Name: {{$LcAcronym}}Tab,
+		Atts: {{$LcAcronym}}Atts,
+		Scan: {{$LcAcronym}}Scan,
+	}
+
+	nvs := []livedb.NameValue{ {Name: "{{.DbName}}", Value: {{.ArgExpr}}} }
+	err := tab.IterateByTsAndXs(ts, nvs, q, func(rec livedb.Record) error {
+		return fn({{$Name}}{Std: rec.Std, {{$LcAcronym}}: rec.Idv.({{$LcAcronym}})})
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
 }{{end}}{{end}}
 
 // THIS FILE HAS BEEN GENERATED BY {{.Generator}} using {{.Input}}.
-// ON {{.Generated}}. DO NOT EDIT.
+{{if .Generated}}// ON {{.Generated}}. DO NOT EDIT.{{else}}// DO NOT EDIT.{{end}}
+// MANUAL CHANGES WILL DISAPPEAR AFTER NEXT RUN OF {{.Generator}}.
+`
+
+const allTmpl = `// Copyright {{.Copyright}}. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// THIS FILE HAS BEEN GENERATED BY {{.Generator}} using {{.Input}}.
+{{if .Generated}}// ON {{.Generated}}. DO NOT EDIT.{{else}}// DO NOT EDIT.{{end}}
 // MANUAL CHANGES WILL DISAPPEAR AFTER NEXT RUN OF {{.Generator}}.
+
+/*
+ {{.File}} provides declarations and functions for all tables
+ generated together with it.
+*/
+
+package {{.Package}}
+
+import (
+	"fmt"
+
+	"github.com/hwheinzen/livedb"
+)
+
+// {{exp "livedbTables"}} lists all tables of package {{.Package}}
+// in the order of their JSON descriptions.
+var {{exp "livedbTables"}} = []livedb.Table{ {{range .Tables}}
+	{
+		Name:   {{exp .LcAcronym}}Tab,
+		Defs:   {{exp .LcAcronym}}Defs,
+		Atts:   {{exp .LcAcronym}}Atts,
+		Scan:   {{exp .LcAcronym}}Scan,
+		Vals:   {{exp .LcAcronym}}Vals,
+		Origin: "{{.Origin}}",
+	},{{end}}
+}
+
+// {{exp "createAllTables"}} creates all tables of {{exp "livedbTables"}} unless they exist.
+//
+// NOTE: Mysql commits a transaction implicitly on create table.
+func {{exp "createAllTables"}}(q livedb.Querier) error {
+	fnc := "{{exp "createAllTables"}}"
+
+	for _, create := range []func(livedb.Querier) error{ {{range .Tables}}
+		{{exp "create"}}{{.UcAcronym}},{{end}}
+	} {
+		err := create(q)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	return nil
+}
 `
+
+const testTmpl = `// Copyright {{.Copyright}}. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// THIS FILE HAS BEEN GENERATED BY {{.Generator}} using {{.Input}}.
+{{if .Generated}}// ON {{.Generated}}. DO NOT EDIT.{{else}}// DO NOT EDIT.{{end}}
+// MANUAL CHANGES WILL DISAPPEAR AFTER NEXT RUN OF {{.Generator}}.
+
+//go:build !mysql && !postgresql
+// +build !mysql,!postgresql
+
+/*
+ {{.TestFile}} tests the functions of {{.File}} with Sqlite.
+*/
+
+package {{.Package}}
+
+import ({{if .HTTP}}
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"{{end}}
+	"path/filepath"
+	"reflect"{{if .HTTP}}
+	"strconv"{{end}}
+	"testing"{{if .UsesTime}}
+	"time"{{end}}
+
+	"github.com/hwheinzen/livedb"
+)
+
+// {{.LcAcronym}}Samples returns two samples of {{exp .LcAcronym}} for tests.
+func {{.LcAcronym}}Samples() (sample1, sample2 {{exp .LcAcronym}}) {
+{{range .Atts}}	var a{{.Name}}, b{{.Name}} {{.BaseType}} = {{.Sample1}}, {{.Sample2}}
+{{end}}
+	sample1 = {{exp .LcAcronym}}{ {{range .Atts}}
+		{{.Name}}: {{if ne .GoType .BaseType}}&{{end}}a{{.Name}},{{end}}
+	}
+	sample2 = {{exp .LcAcronym}}{ {{range .Atts}}
+		{{.Name}}: {{if ne .GoType .BaseType}}&{{end}}b{{.Name}},{{end}}
+	}
+	return sample1, sample2
+}
+
+// Test{{.UcAcronym}}Generated tests the generated functions for {{exp .LcAcronym}}Tab
+// with sample values. The transaction is rolled back.
+func Test{{.UcAcronym}}Generated(t *testing.T) {
+	creator := "Test{{.UcAcronym}}Generated"
+
+	if livedb.GDb == nil {
+		err := livedb.Open(filepath.Join(t.TempDir(), "{{.LcAcronym}}.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer livedb.Close()
+	}
+
+	tx, err := livedb.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer livedb.Rollback(tx) // leave no test data
+
+	err = {{exp "create"}}{{.UcAcronym}}(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sample1, sample2 := {{.LcAcronym}}Samples()
+	_ = sample2 // no change, if samples don't differ
+
+	xp, err := {{exp "empty"}}{{.UcAcronym}}(creator, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := xp.ID
+
+	// expect checks that xs contains exactly one record of ID id with attributes want.
+	expect := func(fnc string, xs []{{exp .LcName}}, err error, want {{exp .LcAcronym}}) {
+		t.Helper()
+		var got []{{exp .LcName}}
+		for _, x := range xs {
+			if x.ID == id {
+				got = append(got, x)
+			}
+		}
+		switch {
+		case err != nil:
+			t.Fatal(fnc, err)
+		case len(got) != 1:
+			t.Fatal(fnc, "expected 1 record, got", len(got))
+		case !reflect.DeepEqual({{exp .LcAcronym}}Vals(got[0].{{exp .LcAcronym}}), {{exp .LcAcronym}}Vals(want)):
+			t.Fatal(fnc, "expected", want, "got", got[0].{{exp .LcAcronym}})
+		}
+	}
+
+	xp.{{exp .LcAcronym}} = sample1
+	key, err := {{exp "start"}}{{.UcAcronym}}(xp, "2999-01-01 00:00:00", creator, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xs, err := {{exp .LcAcronym}}ByKey(key, tx)
+	expect("{{exp .LcAcronym}}ByKey", xs, err, sample1)
+	xs, err = {{exp .LcAcronym}}sByTs("2999-01-01 00:00:00", tx)
+	expect("{{exp .LcAcronym}}sByTs", xs, err, sample1)
+	xs, err = {{exp .LcAcronym}}ByIDTs(id, "2999-02-01 00:00:00", tx)
+	expect("{{exp .LcAcronym}}ByIDTs", xs, err, sample1)
+	xs, err = {{exp .LcAcronym}}ByIDBegin(id, "2999-01-01 00:00:00", tx)
+	expect("{{exp .LcAcronym}}ByIDBegin", xs, err, sample1)
+{{if .CanChange}}
+	pair := &{{exp .LcAcronym}}Pair{Old: xs[0], New: xs[0]}
+	pair.New.{{exp .LcAcronym}} = sample2
+	key, err = {{exp "change"}}{{.UcAcronym}}(pair, "2999-07-01 00:00:00", creator, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xs, err = {{exp .LcAcronym}}ByKey(key, tx)
+	expect("{{exp .LcAcronym}}ByKey", xs, err, sample2)
+	xs, err = {{exp .LcAcronym}}ByIDUntil(id, "2999-07-01 00:00:00", tx)
+	expect("{{exp .LcAcronym}}ByIDUntil", xs, err, sample1)
+	xs, err = {{exp .LcAcronym}}ByIDTs(id, "2999-08-01 00:00:00", tx)
+	expect("{{exp .LcAcronym}}ByIDTs", xs, err, sample2)
+{{end}}{{$LcAcronym := exp .LcAcronym}}{{$CanChange := .CanChange}}{{range .Atts}}{{if .ReadBy}}
+	xs, err = {{$LcAcronym}}sBy{{.Name}}Ts({{if ne .GoType .BaseType}}*{{end}}sample{{if $CanChange}}2{{else}}1{{end}}.{{.Name}}, "2999-08-01 00:00:00", tx)
+	expect("{{$LcAcronym}}sBy{{.Name}}Ts", xs, err, sample{{if $CanChange}}2{{else}}1{{end}}){{end}}{{end}}
+{{if .CanChange}}
+	xs, err = {{exp .LcAcronym}}ByIDBegin(id, "2999-07-01 00:00:00", tx)
+	expect("{{exp .LcAcronym}}ByIDBegin", xs, err, sample2)
+	_, err = {{exp "moveBegin"}}{{.UcAcronym}}(&xs[0], "2999-06-01 00:00:00", creator, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs, err = {{exp .LcAcronym}}ByIDUntil(id, "2999-06-01 00:00:00", tx) // predecessor follows
+	expect("{{exp .LcAcronym}}ByIDUntil", xs, err, sample1)
+
+	_, err = {{exp "moveUntil"}}{{.UcAcronym}}(&xs[0], "2999-05-01 00:00:00", creator, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs, err = {{exp .LcAcronym}}ByIDBegin(id, "2999-05-01 00:00:00", tx) // follower follows
+	expect("{{exp .LcAcronym}}ByIDBegin", xs, err, sample2)
+{{end}}
+	last := xs[0].{{exp .LcAcronym}}
+	_, err = {{exp "terminate"}}{{.UcAcronym}}(&xs[0], "2999-12-01 00:00:00", creator, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs, err = {{exp .LcAcronym}}ByIDUntil(id, "2999-12-01 00:00:00", tx)
+	expect("{{exp .LcAcronym}}ByIDUntil", xs, err, last)
+	xs, err = {{exp .LcAcronym}}ByIDTs(id, "3000-01-01 00:00:00", tx)
+	if err != nil || len(xs) != 0 {
+		t.Fatal("{{exp .LcAcronym}}ByIDTs", "expected no record after termination, got", xs, err)
+	}
+}
+{{if .HTTP}}
+// Test{{.UcAcronym}}Handler tests {{exp .LcAcronym}}Handler with httptest
+// on a temporary database.
+func Test{{.UcAcronym}}Handler(t *testing.T) {
+	if livedb.GDb != nil {
+		t.Skip("livedb.GDb is open, the handler would commit test data")
+	}
+	err := livedb.Open(filepath.Join(t.TempDir(), "{{.LcAcronym}}http.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer livedb.Close()
+
+	err = {{exp "create"}}{{.UcAcronym}}(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &{{exp .LcAcronym}}Handler{
+		Prefix: "{{.Prefix}}",
+		Log:    func(err error, r *http.Request) { t.Log(r.Method, r.URL, err) },
+	}
+
+	// do sends a request with JSON body in (if any) and decodes
+	// a successful response into out (if any). It returns the status code.
+	do := func(method, target string, in, out interface{}) int {
+		t.Helper()
+		var body io.Reader
+		if in != nil {
+			b, err := json.Marshal(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body = bytes.NewReader(b)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, target, body))
+		if w.Code >= 300 {
+			t.Log(method, target, w.Code, w.Body.String())
+		} else if out != nil {
+			err := json.Unmarshal(w.Body.Bytes(), out)
+			if err != nil {
+				t.Fatal(method, target, err)
+			}
+		}
+		return w.Code
+	}
+
+	// expect checks status code and attributes.
+	expect := func(what string, code, wantCode int, got, want {{exp .LcAcronym}}) {
+		t.Helper()
+		switch {
+		case code != wantCode:
+			t.Fatal(what, "expected status", wantCode, "got", code)
+		case !reflect.DeepEqual({{exp .LcAcronym}}Vals(got), {{exp .LcAcronym}}Vals(want)):
+			t.Fatal(what, "expected", want, "got", got)
+		}
+	}
+
+	sample1, sample2 := {{.LcAcronym}}Samples()
+	_ = sample2 // no change, if samples don't differ
+
+	var x {{exp .LcName}}
+	code := do(http.MethodPost, "{{.Prefix}}?ts=2999-01-01%2000:00:00", sample1, &x)
+	expect("POST", code, http.StatusCreated, x.{{exp .LcAcronym}}, sample1)
+	path := "{{.Prefix}}/" + strconv.Itoa(x.ID)
+
+	code = do(http.MethodGet, path+"?ts=2999-02-01%2000:00:00", nil, &x)
+	expect("GET", code, http.StatusOK, x.{{exp .LcAcronym}}, sample1)
+{{if .CanChange}}
+	pair := {{exp .LcAcronym}}Pair{Old: x, New: x}
+	pair.New.{{exp .LcAcronym}} = sample2
+	code = do(http.MethodPut, path+"?ts=2999-07-01%2000:00:00", pair, &x)
+	expect("PUT", code, http.StatusOK, x.{{exp .LcAcronym}}, sample2)
+
+	code = do(http.MethodPut, path+"?ts=2999-08-01%2000:00:00", pair, nil) // old is outdated
+	if code != http.StatusConflict {
+		t.Fatal("PUT", "expected status", http.StatusConflict, "got", code)
+	}
+{{end}}
+	var xs []{{exp .LcName}}
+	code = do(http.MethodGet, path+"/history", nil, &xs)
+	if code != http.StatusOK || len(xs) != {{if .CanChange}}2{{else}}1{{end}} {
+		t.Fatal("GET history", "expected status", http.StatusOK, "and {{if .CanChange}}2 records{{else}}1 record{{end}}, got", code, len(xs))
+	}
+
+	for _, v := range []struct {
+		method, target string
+		want           int
+	}{
+		{http.MethodDelete, path + "?ts=2999-12-01%2000:00:00", http.StatusNoContent},
+		{http.MethodGet, path + "?ts=3000-01-01%2000:00:00", http.StatusNotFound},
+		{http.MethodGet, "{{.Prefix}}/x", http.StatusBadRequest},
+		{http.MethodGet, "{{.Prefix}}XYZ", http.StatusNotFound},
+		{http.MethodPost, "{{.Prefix}}?ts=1999-01-01%2000:00:00", http.StatusBadRequest}, // past
+		{http.MethodPatch, path, http.StatusMethodNotAllowed},
+	} {
+		var in interface{}
+		if v.method == http.MethodPost {
+			in = sample1
+		}
+		code = do(v.method, v.target, in, nil)
+		if code != v.want {
+			t.Fatal(v.method, v.target, "expected status", v.want, "got", code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "{{.Prefix}}/x", nil))
+	var body struct{ Code, Error string }
+	err = json.Unmarshal(w.Body.Bytes(), &body)
+	if err != nil || body.Code != "invalid" || body.Error != "id invalid:x" {
+		t.Fatal("GET", "expected code invalid and message without call chain, got", w.Body.String())
+	}
+}
+{{end}}`
+
+const httpTmpl = `// Copyright {{.Copyright}}. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// THIS FILE HAS BEEN GENERATED BY {{.Generator}} using {{.Input}}.
+{{if .Generated}}// ON {{.Generated}}. DO NOT EDIT.{{else}}// DO NOT EDIT.{{end}}
+// MANUAL CHANGES WILL DISAPPEAR AFTER NEXT RUN OF {{.Generator}}.
+
+/*
+ {{.HTTPFile}} provides an HTTP handler for the {{.DbName}} table.
+*/
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hwheinzen/livedb"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// {{exp .LcAcronym}}Handler serves {{exp .LcAcronym}}Tab as REST/JSON resource below Prefix:
+//
+//	GET    Prefix?ts=           all {{exp .LcName}}s valid at ts
+//	GET    Prefix/{id}?ts=      the {{exp .LcName}} with ID id valid at ts
+//	GET    Prefix/{id}/history  all records of ID id
+//	POST   Prefix?ts=           starts a new {{exp .LcName}} with the attributes of the body
+//	PUT    Prefix/{id}?ts=      changes with body {"old": <as read>, "new": <changed>}
+//	DELETE Prefix/{id}?ts=      terminates the {{exp .LcName}} valid at ts
+//
+// Timestamp ts defaults to livedb.Now. Writes run in transactions (livedb.RunInTx).
+// Database access is canceled with the request context.
+// Errors are answered with the status code of livedb.HTTPStatus and a JSON body
+// {"code": <code>, "error": <message>}: code is one of "invalid", "not_found",
+// "conflict" and "internal", message is translated and without call chain;
+// violations of validation rules are translated one by one.
+// Internal errors are not disclosed. All errors are logged with their call chain.
+type {{exp .LcAcronym}}Handler struct {
+	Prefix    string                                 // path prefix, e.g. "{{.Prefix}}"
+	Creator   func(r *http.Request) string           // creator of changes, default "http"
+	Translate func(err error, r *http.Request) error // localizes error messages (mistake.Err), default English
+	Log       func(err error, r *http.Request)       // logs errors, default log.Println
+}
+
+// ServeHTTP implements http.Handler.
+func (h *{{exp .LcAcronym}}Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fnc := "{{exp .LcAcronym}}Handler.ServeHTTP"
+
+	prefix := strings.TrimSuffix(h.Prefix, "/")
+	if r.URL.Path != prefix && !strings.HasPrefix(r.URL.Path, prefix+"/") {
+		http.NotFound(w, r)
+		return
+	}
+	var parts []string
+	if rest := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"); rest != "" {
+		parts = strings.Split(rest, "/")
+	}
+
+	ts := r.URL.Query().Get("ts")
+	if ts == "" {
+		ts = livedb.Now
+	}
+	creator := "http"
+	if h.Creator != nil {
+		creator = h.Creator(r)
+	}
+
+	var id int
+	if len(parts) > 0 {
+		var err error
+		id, err = strconv.Atoi(parts[0])
+		if err != nil {
+			h.fail(w, r, fmt.Errorf(fnc+":%w", {{.LcAcronym}}Invalid("id", parts[0])))
+			return
+		}
+	}
+
+	var allow string
+	switch {
+	case len(parts) == 0:
+		allow = "GET, POST"
+		switch r.Method {
+		case http.MethodGet:
+			xs, err := {{exp .LcAcronym}}sByTs(ts, livedb.WithContext(r.Context(), nil))
+			h.reply(w, r, http.StatusOK, xs, err)
+			return
+		case http.MethodPost:
+			h.start(w, r, ts, creator)
+			return
+		}
+	case len(parts) == 1:
+		allow = "GET, PUT, DELETE"
+		switch r.Method {
+		case http.MethodGet:
+			xs, err := {{exp .LcAcronym}}ByIDTs(id, ts, livedb.WithContext(r.Context(), nil))
+			if err == nil && len(xs) == 0 {
+				err = {{.LcAcronym}}NotFound(id)
+			}
+			if err != nil {
+				h.fail(w, r, fmt.Errorf(fnc+":%w", err))
+				return
+			}
+			h.reply(w, r, http.StatusOK, xs[0], nil)
+			return
+		case http.MethodPut:
+			h.change(w, r, id, ts, creator)
+			return
+		case http.MethodDelete:
+			h.terminate(w, r, id, ts, creator)
+			return
+		}
+	case len(parts) == 2 && parts[1] == "history":
+		allow = "GET"
+		if r.Method == http.MethodGet {
+			xs, err := {{exp .LcAcronym}}History(id, livedb.WithContext(r.Context(), nil))
+			if err == nil && len(xs) == 0 {
+				err = {{.LcAcronym}}NotFound(id)
+			}
+			h.reply(w, r, http.StatusOK, xs, err)
+			return
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Allow", allow)
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
+// start handles POST.
+func (h *{{exp .LcAcronym}}Handler) start(w http.ResponseWriter, r *http.Request, ts, creator string) {
+	fnc := "{{exp .LcAcronym}}Handler.start"
+
+	var in {{exp .LcAcronym}}
+	err := {{.LcAcronym}}Decode(r, &in)
+	if err != nil {
+		h.fail(w, r, fmt.Errorf(fnc+":%w", err))
+		return
+	}
+
+	var x {{exp .LcName}}
+	_, err = livedb.RunInTx(r.Context(), nil, func(tx *livedb.Tx) error {
+		xp, err := {{exp "empty"}}{{.UcAcronym}}(creator, tx)
+		if err != nil {
+			return err
+		}
+		xp.{{exp .LcAcronym}} = in
+		key, err := {{exp "start"}}{{.UcAcronym}}(xp, ts, creator, tx)
+		if err != nil {
+			return err
+		}
+		xs, err := {{exp .LcAcronym}}ByKey(key, tx)
+		if err != nil {
+			return err
+		}
+		x = xs[0]
+		return nil
+	}, nil)
+	if err != nil {
+		h.fail(w, r, fmt.Errorf(fnc+":%w", err))
+		return
+	}
+
+	w.Header().Set("Location", strings.TrimSuffix(h.Prefix, "/")+"/"+strconv.Itoa(x.ID))
+	h.reply(w, r, http.StatusCreated, x, nil)
+}
+
+// change handles PUT.
+func (h *{{exp .LcAcronym}}Handler) change(w http.ResponseWriter, r *http.Request, id int, ts, creator string) {
+	fnc := "{{exp .LcAcronym}}Handler.change"
+
+	var pair {{exp .LcAcronym}}Pair
+	err := {{.LcAcronym}}Decode(r, &pair)
+	if err == nil && pair.Old.ID != id {
+		err = {{.LcAcronym}}Invalid("old.id", strconv.Itoa(pair.Old.ID))
+	}
+	if err != nil {
+		h.fail(w, r, fmt.Errorf(fnc+":%w", err))
+		return
+	}
+
+	var x {{exp .LcName}}
+	_, err = livedb.RunInTx(r.Context(), nil, func(tx *livedb.Tx) error {
+		key, err := {{exp "change"}}{{.UcAcronym}}(&pair, ts, creator, tx)
+		if err != nil {
+			return err
+		}
+		xs, err := {{exp .LcAcronym}}ByKey(key, tx)
+		if err != nil {
+			return err
+		}
+		x = xs[0]
+		return nil
+	}, nil)
+	if err != nil {
+		h.fail(w, r, fmt.Errorf(fnc+":%w", err))
+		return
+	}
+
+	h.reply(w, r, http.StatusOK, x, nil)
+}
+
+// terminate handles DELETE.
+func (h *{{exp .LcAcronym}}Handler) terminate(w http.ResponseWriter, r *http.Request, id int, ts, creator string) {
+	fnc := "{{exp .LcAcronym}}Handler.terminate"
+
+	_, err := livedb.RunInTx(r.Context(), nil, func(tx *livedb.Tx) error {
+		xs, err := {{exp .LcAcronym}}ByIDTs(id, ts, tx)
+		if err != nil {
+			return err
+		}
+		if len(xs) == 0 {
+			return {{.LcAcronym}}NotFound(id)
+		}
+		_, err = {{exp "terminate"}}{{.UcAcronym}}(&xs[0], ts, creator, tx)
+		return err
+	}, nil)
+	if err != nil {
+		h.fail(w, r, fmt.Errorf(fnc+":%w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reply writes v as JSON with status, or err.
+func (h *{{exp .LcAcronym}}Handler) reply(w http.ResponseWriter, r *http.Request, status int, v interface{}, err error) {
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	if xs, ok := v.([]{{exp .LcName}}); ok && xs == nil {
+		v = []{{exp .LcName}}{} // [] instead of null
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// fail logs err and writes its code and message as JSON
+// with the status code of livedb.HTTPStatus.
+func (h *{{exp .LcAcronym}}Handler) fail(w http.ResponseWriter, r *http.Request, err error) {
+	if h.Log != nil {
+		h.Log(err, r)
+	} else {
+		log.Println(r.Method, r.URL, err)
+	}
+
+	status := livedb.HTTPStatus(err)
+	code, msg := "internal", http.StatusText(status)
+	var e Err{{if .HasRules}}
+	var fs {{exp .LcAcronym}}Fails{{end}}
+	switch {
+	case status == http.StatusInternalServerError:{{if .HasRules}}
+	case errors.As(err, &fs): // each violation translated
+		code = "invalid"
+		msgs := make([]string, len(fs))
+		for i, f := range fs {
+			msgs[i] = h.message(f, r)
+		}
+		msg = strings.Join(msgs, ", "){{end}}
+	case errors.As(err, &e):
+		switch status {
+		case http.StatusBadRequest:
+			code = "invalid"
+		case http.StatusNotFound:
+			code = "not_found"
+		case http.StatusConflict:
+			code = "conflict"
+		}
+		msg = h.message(e, r)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Code  string ` + "`" + `json:"code"` + "`" + `
+		Error string ` + "`" + `json:"error"` + "`" + `
+	}{code, msg})
+}
+
+// message returns the message of e translated by Translate, by default
+// the English message of livedb or else the message without "<PACKAGE>:".
+func (h *{{exp .LcAcronym}}Handler) message(e Err, r *http.Request) string {
+	if h.Translate != nil {
+		if out := h.Translate(e, r); out != nil {
+			return out.Error()
+		}
+	}
+	out, err := livedb.L10nLocalizeError(e, "en")
+	if err == nil && out != nil {
+		return out.Error()
+	}
+
+	fix := e.Fix
+	if i := strings.Index(fix, ":"); i >= 0 {
+		fix = fix[i+1:]
+	}
+	msg, err := livedb.L10nReplace(fix, e.Var)
+	if err != nil {
+		return fix
+	}
+	return msg
+}
+
+// {{.LcAcronym}}Decode decodes the JSON body of r into v; unknown fields are invalid.
+func {{.LcAcronym}}Decode(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err != nil {
+		return {{.LcAcronym}}Invalid("body", err.Error())
+	}
+	return nil
+}
+
+// {{.LcAcronym}}Invalid returns the error for an invalid request value.
+func {{.LcAcronym}}Invalid(name, value string) error {
+	return livedb.MarkErr(livedb.ErrInvalid, Err{
+		Fix: "{{.UCPackage}}:{{.NameTemplate}} invalid:{{.Nam2Template}}",
+		Var: []struct {
+			Name  string
+			Value interface{}
+		}{
+			{"Name", name},
+			{"Nam2", value},
+		},
+	})
+}
+
+// {{.LcAcronym}}NotFound returns the error for a missing {{exp .LcName}}.
+func {{.LcAcronym}}NotFound(id int) error {
+	return livedb.MarkErr(livedb.ErrNotFound, Err{
+		Fix: "{{.UCPackage}}:{{.NameTemplate}} not found",
+		Var: []struct {
+			Name  string
+			Value interface{}
+		}{
+			{"Name", "{{exp .LcName}} " + strconv.Itoa(id)},
+		},
+	})
+}
+`
+
+const tsTmpl = `// Copyright {{.Copyright}}. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// THIS FILE HAS BEEN GENERATED BY {{.Generator}} using {{.Input}}.
+{{if .Generated}}// ON {{.Generated}}. DO NOT EDIT.{{else}}// DO NOT EDIT.{{end}}
+// MANUAL CHANGES WILL DISAPPEAR AFTER NEXT RUN OF {{.Generator}}.
+
+// {{.TSFile}} declares the JSON types of the {{.DbName}} table.
+// Timestamps of Std are strings like "2021-03-04 05:06:07.000",
+// dates and timestamps of attributes RFC 3339 strings,
+// decimals strings like "12.34", bytes base64 strings.
+
+/** Std contains the livedb standard attributes (livedb.Std). */
+export interface Std {
+	id: number;
+	begin: string; // valid from and including
+	until: string; // valid until and excluding
+	pkey: number;
+	created: string;
+	{{.StdJSON "CreatedBy"}}: string;
+	ended?: string; // terminated
+	{{.StdJSON "EndedBy"}}?: string;
+}
+
+/** {{.UcAcronym}} contains the specific attributes of {{.Name}}. */
+export interface {{.UcAcronym}} {{"{"}}{{range .Atts}}{{if .JSONField}}
+	{{.TSField}}: {{.TSType}};{{end}}{{end}}
+}
+
+/** {{.Name}} contains all attributes of table {{.DbName}}. */
+export interface {{.Name}} extends Std, {{.UcAcronym}} {}
+
+/** {{.UcAcronym}}Pair contains old (as read) and new {{.Name}} of a change. */
+export interface {{.UcAcronym}}Pair {
+	{{if .OldTag}}old{{else}}Old{{end}}: {{.Name}};
+	{{if .NewTag}}new{{else}}New{{end}}: {{.Name}};
+}
+`
+
+const ddlTmpl = `-- Copyright {{.Copyright}}. All rights reserved.
+-- Use of this source code is governed by a license
+-- that can be found in the LICENSE file.
+
+-- THIS FILE HAS BEEN GENERATED BY {{.Generator}} using {{.Input}}.
+{{if .Generated}}-- ON {{.Generated}}. DO NOT EDIT.{{else}}-- DO NOT EDIT.{{end}}
+-- MANUAL CHANGES WILL DISAPPEAR AFTER NEXT RUN OF {{.Generator}}.
+
+-- {{.File}} creates {{.Desc}}
+-- in {{.Dialect}} syntax exactly as livedb does. Table.Create registers existing tables
+-- in livedb_tables when the application starts.
+{{range .Stmts}}
+{{.}}
+{{end}}`
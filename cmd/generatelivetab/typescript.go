@@ -0,0 +1,57 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tsKinds maps Kinds to TypeScript types of their JSON values.
+var tsKinds = map[string]string{
+	"string":    "string",
+	"int":       "number",
+	"bool":      "boolean",
+	"float":     "number",
+	"decimal":   "string", // exact decimal, e.g. "12.34"
+	"date":      "string", // RFC 3339 (time.Time)
+	"timestamp": "string", // RFC 3339 (time.Time)
+	"bytes":     "string", // base64
+}
+
+// tsIdent matches property names which need no quotes.
+var tsIdent = regexp.MustCompile(`^[\p{L}_$][\p{L}\p{N}_$]*$`)
+
+// setTSType computes the TypeScript property (TSField) and type (TSType)
+// of att. It needs the values computed by setKind, setRules and setTag.
+//
+// Properties omitted if empty are optional, Nullable attributes
+// which are not Required may be null (unless omitted if empty).
+// Enum gives a union of literals.
+func setTSType(att *Att) {
+	att.TSField = att.JSONField
+	if !tsIdent.MatchString(att.TSField) {
+		att.TSField = fmt.Sprintf("%q", att.TSField)
+	}
+	if att.JSONOmit && !att.Required { // Required are never empty
+		att.TSField += "?"
+	}
+
+	types := []string{tsKinds[att.Kind]}
+	if len(att.Enum) > 0 {
+		types = nil
+		for _, e := range att.Enum {
+			types = append(types, fmt.Sprintf("%q", e))
+		}
+		if !att.Required { // empty strings are not checked
+			types = append(types, `""`)
+		}
+	}
+	if att.Nullable && !att.Required && !att.JSONOmit {
+		types = append(types, "null")
+	}
+	att.TSType = strings.Join(types, " | ")
+}
@@ -13,7 +13,7 @@ import (
 
 // IsTmsp returns true if the given string conforms to the timestamp
 // format 'YYYY-MM-DD HH:MM:SS.sss' and if it is valid.
-func IsTmsp(tmsp string, tx *sql.Tx) (bool, error) {
+func IsTmsp(tmsp string, q Querier) (bool, error) {
 	fnc := "IsTmsp"
 
 	s := "select " + FormatTmsp(1) + ";"
@@ -24,11 +24,7 @@ func IsTmsp(tmsp string, tx *sql.Tx) (bool, error) {
 	rows := &sql.Rows{}
 	var err error
 
-	if tx != nil {
-		rows, err = tx.Query(s, tmsp)
-	} else {
-		rows, err = GDb.Query(s, tmsp)
-	}
+	rows, err = dbQuery(q, s, tmsp)
 	if err != nil {
 		return false, nil // we asume postgres reported invalid date
 		// 		e := Err{Fix: "LIVEDB:error executing query"}
@@ -63,7 +59,7 @@ func IsTmsp(tmsp string, tx *sql.Tx) (bool, error) {
 
 // CurrentTmsp returns the current timestamp at timezone UTC
 // as string formatted as 'YYYY-MM-DD HH:MM:SS.sss'.
-func CurrentTmsp(tx *sql.Tx) (string, error) {
+func CurrentTmsp(q Querier) (string, error) {
 	fnc := "CurrentTmsp"
 
 	s := "select " + FormatNow() + ";"
@@ -73,11 +69,7 @@ func CurrentTmsp(tx *sql.Tx) (string, error) {
 	rows := &sql.Rows{}
 	var err error
 
-	if tx != nil {
-		rows, err = tx.Query(s)
-	} else {
-		rows, err = GDb.Query(s)
-	}
+	rows, err = dbQuery(q, s)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
 			Var: []struct {
@@ -115,7 +107,7 @@ func CurrentTmsp(tx *sql.Tx) (string, error) {
 // of a reference timestamp.
 //
 // NOTE: The compare stops at seconds.
-func CmpTmspRef(tmsp, ref string, tx *sql.Tx) (past, present, future bool, err error) {
+func CmpTmspRef(tmsp, ref string, q Querier) (past, present, future bool, err error) {
 	fnc := "CmpTmspRef"
 
 	if len(ref) < 19 {
@@ -130,7 +122,7 @@ func CmpTmspRef(tmsp, ref string, tx *sql.Tx) (past, present, future bool, err e
 				{Name: "Int", Value: 19},
 			},
 		}
-		return false, false, false, fmt.Errorf(fnc+":%w", err)
+		return false, false, false, fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
 	}
 	if len(tmsp) < 19 {
 		err := Err{
@@ -144,7 +136,7 @@ func CmpTmspRef(tmsp, ref string, tx *sql.Tx) (past, present, future bool, err e
 				{Name: "Int", Value: 19},
 			},
 		}
-		return false, false, false, fmt.Errorf(fnc+":%w", err)
+		return false, false, false, fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
 	}
 
 	s := "select " + FormatDiffTmsp(&ref, &tmsp) + ";"
@@ -154,11 +146,7 @@ func CmpTmspRef(tmsp, ref string, tx *sql.Tx) (past, present, future bool, err e
 
 	rows := &sql.Rows{}
 
-	if tx != nil {
-		rows, err = tx.Query(s, ref[:19], tmsp[:19])
-	} else {
-		rows, err = GDb.Query(s, ref[:19], tmsp[:19])
-	}
+	rows, err = dbQuery(q, s, ref[:19], tmsp[:19])
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
 			Var: []struct {
@@ -202,7 +190,7 @@ func CmpTmspRef(tmsp, ref string, tx *sql.Tx) (past, present, future bool, err e
 // CmpTmspNow let the database check if tmsp is past, present or future.
 //
 // NOTE: The compare stops at seconds.
-func CmpTmspNow(tmsp string, tx *sql.Tx) (past, present, future bool, err error) {
+func CmpTmspNow(tmsp string, q Querier) (past, present, future bool, err error) {
 	fnc := "CmpTmspNow"
 
 	if len(tmsp) < 19 {
@@ -217,7 +205,7 @@ func CmpTmspNow(tmsp string, tx *sql.Tx) (past, present, future bool, err error)
 				{Name: "Int", Value: 19},
 			},
 		}
-		return false, false, false, fmt.Errorf(fnc+":%w", err)
+		return false, false, false, fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
 	}
 
 	s := "select " + FormatDiffNow() + ";"
@@ -227,11 +215,7 @@ func CmpTmspNow(tmsp string, tx *sql.Tx) (past, present, future bool, err error)
 
 	rows := &sql.Rows{}
 
-	if tx != nil {
-		rows, err = tx.Query(s, tmsp[:19])
-	} else {
-		rows, err = GDb.Query(s, tmsp[:19])
-	}
+	rows, err = dbQuery(q, s, tmsp[:19])
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
 			Var: []struct {
@@ -277,12 +261,12 @@ func CmpTmspNow(tmsp string, tx *sql.Tx) (past, present, future bool, err error)
 // ("now" is also valid).
 //
 // NOTE: The compare stops at seconds.
-func Tmsp(in string, tx *sql.Tx) (out string, past, present, future bool, err error) {
+func Tmsp(in string, q Querier) (out string, past, present, future bool, err error) {
 	fnc := "Tmsp"
 
 	if in == Now {
 
-		out, err = CurrentTmsp(tx)
+		out, err = CurrentTmsp(q)
 		if err != nil {
 			return "", false, false, false, fmt.Errorf(fnc+":%w", err)
 		}
@@ -291,16 +275,16 @@ func Tmsp(in string, tx *sql.Tx) (out string, past, present, future bool, err er
 	} else {
 
 		var ok bool
-		ok, err = IsTmsp(in, tx)
+		ok, err = IsTmsp(in, q)
 		if err != nil {
 			return "", false, false, false, fmt.Errorf(fnc+":%w", err)
 		}
 		if !ok {
 			err = Err{Fix: "LIVEDB:not a valid timestamp"}
-			return "", false, false, false, fmt.Errorf(fnc+":%w", err)
+			return "", false, false, false, fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
 		}
 
-		past, present, future, err = CmpTmspNow(in, tx)
+		past, present, future, err = CmpTmspNow(in, q)
 		if err != nil {
 			return "", false, false, false, fmt.Errorf(fnc+":%w", err)
 		}
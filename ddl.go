@@ -0,0 +1,216 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// dialect contains the SQL specifics of a database needed to create
+// livedb tables. The build tags select the one used (gDialect),
+// DDL may use all of them.
+type dialect struct {
+	name      string
+	quote     func(name string) string
+	stdDefs   []string
+	stdIDDefs []string
+}
+
+var sqliteDialect = dialect{
+	name:  "sqlite",
+	quote: func(name string) string { return `"` + name + `"` },
+	stdDefs: []string{
+		"id integer not null",
+		"begin varchar(26) not null",
+		"until varchar(26)",
+		"pkey integer primary key autoincrement",
+		"created varchar(26) not null",
+		"createdby varchar(50) not null",
+		"ended varchar(26)", // 'terminated' is reserved word for MariaDB/MySQL
+		"endedby varchar(50)",
+	},
+	stdIDDefs: []string{
+		"id integer primary key autoincrement",
+		"created varchar(26) not null",
+		"createdby varchar(50) not null",
+		"usedby varchar(50)",
+	},
+}
+
+var postgresqlDialect = dialect{
+	name:  "postgresql",
+	quote: func(name string) string { return `"` + name + `"` },
+	stdDefs: []string{
+		"id integer not null",
+		"begin varchar(26) not null",
+		"until varchar(26)",
+		"pkey serial",
+		"created varchar(26) not null",
+		"createdby varchar(50) not null",
+		"ended varchar(26)", // 'terminated' is reserved word for MariaDB/MySQL
+		"endedby varchar(50)",
+	},
+	stdIDDefs: []string{
+		`"id" serial`,
+		"created varchar(26) not null",
+		"createdby varchar(50) not null",
+		"usedby varchar(50)",
+	},
+}
+
+var mysqlDialect = dialect{
+	name:  "mysql",
+	quote: func(name string) string { return "`" + name + "`" },
+	stdDefs: []string{
+		"id integer not null",
+		"begin varchar(26) not null",
+		"until varchar(26)",
+		"pkey integer auto_increment primary key",
+		"created varchar(26) not null",
+		"createdby varchar(50) not null",
+		"ended varchar(26)", // 'terminated' is reserved word for MariaDB/MySQL
+		"endedby varchar(50)",
+	},
+	stdIDDefs: []string{
+		"id integer auto_increment primary key",
+		"created varchar(26) not null",
+		"createdby varchar(50) not null",
+		"usedby varchar(50)",
+	},
+}
+
+// Dialects are the names of the SQL dialects DDL knows.
+var Dialects = []string{sqliteDialect.name, postgresqlDialect.name, mysqlDialect.name}
+
+// findDialect returns the dialect named name.
+func findDialect(name string) (dialect, error) {
+	fnc := "findDialect"
+
+	for _, d := range []dialect{sqliteDialect, postgresqlDialect, mysqlDialect} {
+		if d.name == name {
+			return d, nil
+		}
+	}
+
+	err := Err{
+		Fix: "LIVEDB:unknown dialect {{.Name}}",
+		Var: []struct {
+			Name  string
+			Value interface{}
+		}{
+			{"Name", name},
+		},
+	}
+	return dialect{}, fmt.Errorf(fnc+":%w", err)
+}
+
+// Table.DDL returns the SQL statements in dialect (see Dialects)
+// creating the table, its ID-table and indexes exactly as Table.Create does.
+// Table.Create registers the table in livedb_tables (see MetaDDL)
+// if it exists already.
+func (t *Table) DDL(dialect string) ([]string, error) {
+	fnc := "Table.DDL"
+
+	d, err := findDialect(dialect)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	if t.Name == "" {
+		err := Err{Fix: "LIVEDB:table name missing"}
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+	err = t.namesPrecs() // no SQL injection
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return []string{
+		d.createTable(t),
+		d.createIDTable(t),
+		d.createIndexIDBegin(t),
+		d.createIndexIDUntil(t),
+	}, nil
+}
+
+// MetaDDL returns the SQL statements in dialect (see Dialects) creating
+// the livedb metadata tables livedb_tables and livedb_migrations
+// exactly as livedb does when needed.
+func MetaDDL(dialect string) ([]string, error) {
+	fnc := "MetaDDL"
+
+	d, err := findDialect(dialect)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return []string{
+		d.createMeta(tablesTab, tablesDefs),
+		d.createMeta(migrationsTab, migrationsDefs),
+	}, nil
+}
+
+// quoteDef returns attribute definition def with quoted name.
+func (d dialect) quoteDef(def string) string {
+	def = strings.TrimSpace(def)
+	i := strings.IndexAny(def, " \t\n")
+	if i < 0 {
+		return d.quote(def)
+	}
+	return d.quote(def[:i]) + def[i:]
+}
+
+// createTable returns the statement creating table t.
+func (d dialect) createTable(t *Table) string {
+	var buf bytes.Buffer
+	var put = buf.WriteString
+
+	put("create table " + d.quote(t.Name) + "(")
+	for _, def := range d.stdDefs {
+		put(def + ",")
+	}
+	for _, def := range t.Defs {
+		put(d.quoteDef(def) + ",")
+	}
+
+	s := buf.String()
+	return s[:len(s)-1] + ");" // replace last comma
+}
+
+// createIDTable returns the statement creating the ID-table of t.
+func (d dialect) createIDTable(t *Table) string {
+	var buf bytes.Buffer
+	var put = buf.WriteString
+
+	put("create table " + d.quote(t.Name+"id") + "(")
+	for _, def := range d.stdIDDefs {
+		put(def + ",")
+	}
+
+	s := buf.String()
+	return s[:len(s)-1] + ");" // replace last comma
+}
+
+// createIndexIDBegin returns the statement creating the unique index
+// on id and begin of t.
+func (d dialect) createIndexIDBegin(t *Table) string {
+	return "create unique index " + d.quote(t.Name+"idxidbegin") + " on " + d.quote(t.Name) + " (id, begin);"
+}
+
+// createIndexIDUntil returns the statement creating the index
+// on id and until of t.
+func (d dialect) createIndexIDUntil(t *Table) string {
+	return "create index " + d.quote(t.Name+"idxiduntil") + " on " + d.quote(t.Name) + " (id, until);"
+}
+
+// createMeta returns the statement creating metadata table name
+// with definitions defs.
+func (d dialect) createMeta(name string, defs []string) string {
+	return "create table " + d.quote(name) + "(" + strings.Join(defs, ",") + ");"
+}
@@ -0,0 +1,133 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for DDL and MetaDDL.
+
+package livedb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const ddltab = "tddl"
+
+// TestDDL tests the statements of all dialects.
+func TestDDL(t *testing.T) {
+
+	type ddlTest struct {
+		dialect string
+		tab     Table
+		//
+		want []string // statements
+		err  bool
+	}
+	ddlTests := []ddlTest{
+		{"sqlite", Table{Name: "tx", Defs: []string{"a varchar(10)"}}, []string{
+			`create table "tx"(id integer not null,begin varchar(26) not null,until varchar(26),` +
+				`pkey integer primary key autoincrement,created varchar(26) not null,createdby varchar(50) not null,` +
+				`ended varchar(26),endedby varchar(50),"a" varchar(10));`,
+			`create table "txid"(id integer primary key autoincrement,created varchar(26) not null,` +
+				`createdby varchar(50) not null,usedby varchar(50));`,
+			`create unique index "txidxidbegin" on "tx" (id, begin);`,
+			`create index "txidxiduntil" on "tx" (id, until);`,
+		}, false},
+		{"postgresql", Table{Name: "tx"}, []string{
+			`create table "tx"(id integer not null,begin varchar(26) not null,until varchar(26),` +
+				`pkey serial,created varchar(26) not null,createdby varchar(50) not null,` +
+				`ended varchar(26),endedby varchar(50));`,
+			`create table "txid"("id" serial,created varchar(26) not null,` +
+				`createdby varchar(50) not null,usedby varchar(50));`,
+			`create unique index "txidxidbegin" on "tx" (id, begin);`,
+			`create index "txidxiduntil" on "tx" (id, until);`,
+		}, false},
+		{"mysql", Table{Name: "tx", Defs: []string{"a integer"}}, []string{
+			"create table `tx`(id integer not null,begin varchar(26) not null,until varchar(26)," +
+				"pkey integer auto_increment primary key,created varchar(26) not null,createdby varchar(50) not null," +
+				"ended varchar(26),endedby varchar(50),`a` integer);",
+			"create table `txid`(id integer auto_increment primary key,created varchar(26) not null," +
+				"createdby varchar(50) not null,usedby varchar(50));",
+			"create unique index `txidxidbegin` on `tx` (id, begin);",
+			"create index `txidxiduntil` on `tx` (id, until);",
+		}, false},
+		{"oracle", Table{Name: "tx"}, nil, true},
+		{"sqlite", Table{}, nil, true},
+		{"sqlite", Table{Name: "tx", Defs: []string{"a integer; drop table tx"}}, nil, true},
+	}
+
+	for i, v := range ddlTests {
+		got, err := v.tab.DDL(v.dialect) // <------- ACTION
+		if err != nil {
+			if !v.err {
+				err = translate(err, lang) // ******** l10n ********
+				t.Error("#"+fmt.Sprintf("%d", i+1), "unexpected error", err)
+			} else {
+				t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+			}
+			continue
+		}
+		if v.err {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error")
+			continue
+		}
+		if strings.Join(got, "\n") != strings.Join(v.want, "\n") {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected\n", strings.Join(v.want, "\n"), "\ngot\n", strings.Join(got, "\n"))
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+
+	for _, d := range Dialects {
+		got, err := MetaDDL(d) // <------- ACTION
+		if err != nil || len(got) != 2 ||
+			!strings.Contains(got[0], tablesTab) || !strings.Contains(got[1], migrationsTab) {
+			t.Error("MetaDDL", d, "unexpected", got, err)
+		}
+	}
+}
+
+// TestDDLCreate tests that a table created by the statements of DDL
+// is taken by Table.Create as created.
+func TestDDLCreate(t *testing.T) {
+
+	creator := "TestDDLCreate"
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer Rollback(tx) // leave no test tables
+
+	tab := Table{Name: ddltab, Defs: []string{"a varchar(10)", "b integer"}, Origin: creator}
+	stmts, err := tab.DDL(gDialect.name) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	for _, s := range stmts {
+		_, err = tx.Exec(s)
+		if err != nil {
+			t.Fatal(s, err)
+		}
+	}
+
+	err = tab.Create(tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if tableInfo(t, ddltab, tx) == nil {
+		t.Fatal("expected", ddltab, "to be registered")
+	}
+	id, err := tab.NewID(creator, tx) // uses the ID-table
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if id < 1 {
+		t.Error("unexpected ID", id)
+	}
+}
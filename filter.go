@@ -0,0 +1,257 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// Filter is a condition on the columns of a table.
+// Filters are built by Eq, Ne, Lt, Le, Gt, Ge, Between, In, Like,
+// IsNull, NotNull and combined by And and Or, e.g.:
+//
+//	f := livedb.And(
+//		livedb.Eq("dept", "sales"),
+//		livedb.Or(livedb.Lt("salary", 1000), livedb.IsNull("salary")),
+//	)
+//	recs, err := t.ByTs(ts, q, livedb.WithFilter(f))
+//
+// Column names must be in StdAtts or t.Atts;
+// values are passed as query arguments.
+type Filter struct {
+	op   string        // comparison, "between", "in", "like", "is null", "is not null", "and", "or"
+	col  string        // column name
+	vals []interface{} // values
+	subs []Filter      // for "and" and "or"
+}
+
+// Eq is the condition col = v.
+func Eq(col string, v interface{}) Filter {
+	return Filter{op: "=", col: col, vals: []interface{}{v}}
+}
+
+// Ne is the condition col <> v.
+func Ne(col string, v interface{}) Filter {
+	return Filter{op: "<>", col: col, vals: []interface{}{v}}
+}
+
+// Lt is the condition col < v.
+func Lt(col string, v interface{}) Filter {
+	return Filter{op: "<", col: col, vals: []interface{}{v}}
+}
+
+// Le is the condition col <= v.
+func Le(col string, v interface{}) Filter {
+	return Filter{op: "<=", col: col, vals: []interface{}{v}}
+}
+
+// Gt is the condition col > v.
+func Gt(col string, v interface{}) Filter {
+	return Filter{op: ">", col: col, vals: []interface{}{v}}
+}
+
+// Ge is the condition col >= v.
+func Ge(col string, v interface{}) Filter {
+	return Filter{op: ">=", col: col, vals: []interface{}{v}}
+}
+
+// Between is the condition col between lo and hi (both inclusive).
+func Between(col string, lo, hi interface{}) Filter {
+	return Filter{op: "between", col: col, vals: []interface{}{lo, hi}}
+}
+
+// In is the condition col in (vs...). At least one value is needed.
+func In(col string, vs ...interface{}) Filter {
+	return Filter{op: "in", col: col, vals: vs}
+}
+
+// Like is the condition col like pattern.
+func Like(col string, pattern string) Filter {
+	return Filter{op: "like", col: col, vals: []interface{}{pattern}}
+}
+
+// IsNull is the condition col is null.
+func IsNull(col string) Filter {
+	return Filter{op: "is null", col: col}
+}
+
+// NotNull is the condition col is not null.
+func NotNull(col string) Filter {
+	return Filter{op: "is not null", col: col}
+}
+
+// And is true if all filters fs are true. At least one filter is needed.
+func And(fs ...Filter) Filter {
+	return Filter{op: "and", subs: fs}
+}
+
+// Or is true if any of the filters fs is true. At least one filter is needed.
+func Or(fs ...Filter) Filter {
+	return Filter{op: "or", subs: fs}
+}
+
+// WithFilter restricts the Records returned by
+// ByTs, ByTsAndXs, ByIDTs, ByPeriod, History and their Iterate variants
+// IterateByTs, IterateByTsAndXs, IterateByPeriod, IterateHistory
+// to those satisfying f.
+func WithFilter(f Filter) func(*Table) {
+	return func(t *Table) {
+		if t.opts != nil { // read call
+			t.opts.filter = &f
+		}
+	}
+}
+
+// isColumn reports whether col names a column of t.
+func (t *Table) isColumn(col string) bool {
+	return t.column(col) != ""
+}
+
+// column returns col quoted if it names a column of t, "" otherwise.
+// Case is ignored; the quoted name is the one of StdAtts or t.Atts.
+func (t *Table) column(col string) string {
+	for _, att := range StdAtts {
+		if strings.EqualFold(col, att) {
+			return quote(att)
+		}
+	}
+	for _, att := range t.Atts {
+		if strings.EqualFold(col, att) {
+			return quote(att)
+		}
+	}
+	return ""
+}
+
+// columnPrecs refuses col if it is no identifier or no column of t.
+func (t *Table) columnPrecs(col string) error {
+	fnc := "Table.columnPrecs"
+
+	if !IsIdent(strings.ToLower(col)) {
+		return fmt.Errorf(fnc+":%w", identErr(col))
+	}
+	if !t.isColumn(col) {
+		err := Err{
+			Fix: "LIVEDB:unknown column {{.Name}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", col},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
+	}
+
+	return nil
+}
+
+// filterPrecs checks columns and values of f.
+func (t *Table) filterPrecs(f Filter) error {
+	fnc := "Table.filterPrecs"
+
+	switch f.op {
+	case "and", "or":
+		if len(f.subs) == 0 {
+			err := Err{
+				Fix: "LIVEDB:empty filter {{.Name}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Name", f.op},
+				},
+			}
+			return fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
+		}
+		for _, sub := range f.subs {
+			err := t.filterPrecs(sub)
+			if err != nil {
+				return fmt.Errorf(fnc+":%w", err)
+			}
+		}
+		return nil
+	case "":
+		err := Err{Fix: "LIVEDB:empty filter {{.Name}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "Filter{}"},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
+	case "in":
+		if len(f.vals) == 0 {
+			err := Err{
+				Fix: "LIVEDB:empty filter {{.Name}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Name", "in " + f.col},
+				},
+			}
+			return fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
+		}
+	}
+
+	err := t.columnPrecs(f.col)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+// putFilter writes the SQL condition of f to buf
+// and appends the values to sqlargs.
+// num is the number of the last argument.
+func (t *Table) putFilter(buf *bytes.Buffer, f Filter, num int, sqlargs []interface{}) (int, []interface{}) {
+	var put = buf.WriteString // write method
+
+	col := t.column(f.col)
+
+	switch f.op {
+	case "and", "or":
+		put("(")
+		for i, sub := range f.subs {
+			if i > 0 {
+				put(" " + f.op + " ")
+			}
+			num, sqlargs = t.putFilter(buf, sub, num, sqlargs)
+		}
+		put(")")
+	case "is null", "is not null":
+		put(col + " " + f.op)
+	case "between":
+		num++
+		put(col + " between " + FormatAtt(num))
+		num++
+		put(" and " + FormatAtt(num))
+		sqlargs = append(sqlargs, f.vals...)
+	case "in":
+		put(col + " in (")
+		for i := range f.vals {
+			if i > 0 {
+				put(",")
+			}
+			num++
+			put(FormatAtt(num))
+		}
+		put(")")
+		sqlargs = append(sqlargs, f.vals...)
+	default: // comparison, like
+		num++
+		put(col + " " + f.op + " " + FormatAtt(num))
+		sqlargs = append(sqlargs, f.vals...)
+	}
+
+	return num, sqlargs
+}
@@ -0,0 +1,236 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for filters of read functions.
+
+package livedb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// startTes inserts some records within transaction tx.
+func startTes(t *testing.T, tx Querier) {
+
+	creator := "startTes"
+
+	tes := []Te{
+		{str2: "0000String"},
+		{str1: "0815String", str2: "4711String", num: 42},
+		{str1: "0816String", str2: "4712String", num: 43},
+		{str1: "0817String", str2: "4713String", num: 44},
+	}
+	for _, te := range tes {
+		tab := Table{Name: tetab, Atts: teAtts, New: Record{Idv: te}, Vals: teVals, Scan: teScan}
+
+		id, err := tab.NewID(creator, tx)
+		if err == nil {
+			_, err = tab.Start(id, Now, creator, tx)
+		}
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestFilter tests ByTs with filters against the same filters applied in Go.
+func TestFilter(t *testing.T) {
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer Rollback(tx) // leave no test records
+
+	startTes(t, tx)
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	recs, err := tab.ByTs(Now, tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	type filterTest struct {
+		f    Filter
+		want func(Te) bool // same condition in Go
+		//
+		ok bool
+	}
+	filterTests := []filterTest{
+		{Eq("num", 42), func(te Te) bool { return te.num == 42 }, true},
+		{Ne("num", 42), func(te Te) bool { return te.num != 42 && te.num != 0 }, true},
+		{Gt("num", 42), func(te Te) bool { return te.num > 42 }, true},
+		{Between("num", 43, 44), func(te Te) bool { return te.num >= 43 && te.num <= 44 }, true},
+		{In("str2", "4711String", "4712String"), func(te Te) bool { return te.str2 == "4711String" || te.str2 == "4712String" }, true},
+		{Like("STR2", "47%"), func(te Te) bool { return strings.HasPrefix(te.str2, "47") }, true},
+		{IsNull("num"), func(te Te) bool { return te.num == 0 }, true},
+		{Or(Lt("num", 43), IsNull("str1")), func(te Te) bool { return te.num != 0 && te.num < 43 || te.str1 == "" }, true},
+		{And(NotNull("num"), Or(Eq("num", 42), Eq("num", 44))), func(te Te) bool { return te.num == 42 || te.num == 44 }, true},
+		{Eq("nosuch", 1), nil, false},
+		{Eq("num=num or 1", 1), nil, false},
+		{In("num"), nil, false},
+		{Or(), nil, false},
+		{And(Eq("num", 1), Filter{}), nil, false},
+	}
+
+	for i, v := range filterTests {
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+		got, err := tab.ByTs(Now, tx, WithFilter(v.f)) // <------- ACTION
+		want := 0
+		if v.want != nil {
+			for _, rec := range recs {
+				if v.want(rec.Idv.(Te)) {
+					want++
+				}
+			}
+		}
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		case len(got) != want:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", want, "records, got", len(got))
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestByTsAndXsNames tests that names of xs must be columns.
+func TestByTsAndXsNames(t *testing.T) {
+
+	type namesTest struct {
+		xs []NameValue
+		//
+		ok bool
+	}
+	namesTests := []namesTest{
+		{[]NameValue{{Name: "num", Value: 42}}, true},
+		{[]NameValue{{Name: "ID", Value: 1}}, true},
+		{[]NameValue{{Name: "num=42 or num", Value: 42}}, false},
+	}
+
+	for i, v := range namesTests {
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+		_, err := tab.ByTsAndXs(Now, v.xs, nil) // <------- ACTION
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestByPeriod tests that a period contains at least the Records of its start.
+func TestByPeriod(t *testing.T) {
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	recs, err := tab.ByTs(Now, nil)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	type periodTest struct {
+		from  string
+		until string
+		//
+		min int // minimum number of Records
+		ok  bool
+	}
+	periodTests := []periodTest{
+		{Now, "9999-12-31 00:00:00.000", len(recs), true},
+		{"1000-01-01 00:00:00.000", "1000-01-02 00:00:00.000", 0, true},
+		{"", Now, 0, false},
+		{Now, "", 0, false},
+	}
+
+	for i, v := range periodTests {
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+		got, err := tab.ByPeriod(v.from, v.until, nil) // <------- ACTION
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		case len(got) < v.min:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected at least", v.min, "records, got", len(got))
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestHistory tests that the history of an ID contains its current Record.
+func TestHistory(t *testing.T) {
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer Rollback(tx) // leave no test records
+
+	startTes(t, tx)
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	recs, err := tab.ByTs(Now, tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	for i, rec := range recs {
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+		hist, err := tab.History(rec.Std.ID, tx) // <------- ACTION
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+			continue
+		}
+		found := false
+		for j, h := range hist {
+			if h.Std.ID != rec.Std.ID {
+				t.Error("#"+fmt.Sprintf("%d", i+1), "record of ID", h.Std.ID, "in history of", rec.Std.ID)
+			}
+			if j > 0 && h.Std.Begin < hist[j-1].Std.Begin {
+				t.Error("#"+fmt.Sprintf("%d", i+1), "history not ordered by begin")
+			}
+			if h.Std.Pkey == rec.Std.Pkey {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "current record missing in history")
+		}
+	}
+}
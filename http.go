@@ -0,0 +1,61 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Kinds of errors, as reported by errors.Is:
+//
+//	errors.Is(err, livedb.ErrConflict)
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict") // concurrent or contradicting change
+	ErrInvalid  = errors.New("invalid")  // invalid timestamp, filter, attribute etc.
+)
+
+// kindErr is an error marked as being of a kind (see MarkErr).
+type kindErr struct {
+	kind error
+	err  error
+}
+
+func (e kindErr) Error() string        { return e.err.Error() }
+func (e kindErr) Unwrap() error        { return e.err }
+func (e kindErr) Is(target error) bool { return target == e.kind }
+
+// MarkErr returns err marked as being of kind ErrNotFound, ErrConflict
+// or ErrInvalid: errors.Is reports kind then. The message of err
+// and its chain (errors.As, errors.Unwrap, translation) remain.
+func MarkErr(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return kindErr{kind: kind, err: err}
+}
+
+// HTTPStatus returns the HTTP status code fitting err as returned
+// by livedb or code generated by generatelivetab:
+//
+//	http.StatusOK                  err is nil
+//	http.StatusNotFound            errors.Is(err, ErrNotFound)
+//	http.StatusConflict            errors.Is(err, ErrConflict)
+//	http.StatusBadRequest          errors.Is(err, ErrInvalid)
+//	http.StatusInternalServerError all other errors
+func HTTPStatus(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalid):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
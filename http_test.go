@@ -0,0 +1,64 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for HTTPStatus.
+
+package livedb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// TestHTTPStatus tests the status codes of typical errors.
+func TestHTTPStatus(t *testing.T) {
+
+	type httpStatusTest struct {
+		err error
+		//
+		want int
+	}
+	httpStatusTests := []httpStatusTest{
+		{nil, http.StatusOK},
+		{errors.New("plain"), http.StatusInternalServerError},
+		{fmt.Errorf("fnc:%w", MarkErr(ErrNotFound, Err{Fix: "EXAMPLE:{{.Name}} not found"})), http.StatusNotFound},
+		{fmt.Errorf("fnc:%w", MarkErr(ErrInvalid, Err{Fix: "EXAMPLE:{{.Name}} invalid:{{.Nam2}}"})), http.StatusBadRequest},
+		{fmt.Errorf("fnc:%w", Err{Fix: "EXAMPLE:{{.Name}} not found"}), http.StatusInternalServerError}, // not marked
+		{fmt.Errorf("fnc:%w", MarkErr(ErrConflict, Err{Fix: "LIVEDB:competetively changed"})), http.StatusConflict},
+		{fmt.Errorf("fnc:%w:driver", Err{Fix: "LIVEDB:error executing update"}), http.StatusInternalServerError},
+	}
+
+	for i, v := range httpStatusTests {
+		got := HTTPStatus(v.err) // <------- ACTION
+		if got != v.want {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.want, "got", got)
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+}
+
+// TestHTTPStatusLivedb tests the status codes of errors returned by livedb.
+func TestHTTPStatusLivedb(t *testing.T) {
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	_, _, _, _, err := Tmsp("no timestamp", nil) // <------- ACTION
+	if got := HTTPStatus(err); got != http.StatusBadRequest {
+		t.Error("expected", http.StatusBadRequest, "got", got, err)
+	}
+	_, err = tab.ByTs(Now, nil, WithLimit(-1)) // <------- ACTION
+	if got := HTTPStatus(err); got != http.StatusBadRequest {
+		t.Error("expected", http.StatusBadRequest, "got", got, err)
+	}
+
+	err = translate(MarkErr(ErrInvalid, Err{Fix: "LIVEDB:not a valid timestamp"}), "de") // ******** l10n ********
+	if err == nil || err.Error() != "kein gültiger Zeitstempel" {
+		t.Error("expected translated message, got", err)
+	}
+}
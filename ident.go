@@ -0,0 +1,97 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// Table and column names are put into SQL statements as identifiers.
+// To prevent SQL injection livedb accepts only lower case ASCII letters,
+// digits and underscores, and quotes all identifiers (see quote).
+
+// maxIdentLen is the maximum length of identifiers (Postgres' limit).
+const maxIdentLen = 63
+
+// maxSuffixLen is the length of the longest suffix
+// livedb appends to table names (index "idxiduntil").
+const maxSuffixLen = len("idxiduntil")
+
+// MaxTableNameLen is the maximum length of table names:
+// it leaves room for the suffixes livedb appends to them.
+const MaxTableNameLen = maxIdentLen - maxSuffixLen
+
+// IsIdent reports whether name is a valid livedb identifier:
+// a lower case ASCII letter or underscore followed by lower case
+// ASCII letters, digits or underscores, 63 characters at most.
+func IsIdent(name string) bool {
+	if name == "" || len(name) > maxIdentLen {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c == '_':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ErrIdent is reported by errors.Is for table, attribute and column names
+// that are no valid identifiers. Such errors are of kind ErrInvalid, too.
+var ErrIdent = errors.New("invalid identifier")
+
+// identErr returns the error for an invalid identifier.
+func identErr(name string) error {
+	err := Err{
+		Fix: "LIVEDB:invalid identifier: {{.Name}}",
+		Var: []struct {
+			Name  string
+			Value interface{}
+		}{
+			{"Name", name},
+		},
+	}
+	return MarkErr(ErrIdent, MarkErr(ErrInvalid, err))
+}
+
+// namesPrecs refuses table and attribute names that are no valid identifiers
+// and attribute definitions that could contain more than one SQL clause.
+func (t *Table) namesPrecs() error {
+	fnc := "Table.namesPrecs"
+
+	if !IsIdent(t.Name) || len(t.Name) > MaxTableNameLen {
+		return fmt.Errorf(fnc+":%w", identErr(t.Name))
+	}
+
+	for _, att := range t.Atts {
+		if !IsIdent(att) {
+			return fmt.Errorf(fnc+":%w", identErr(att))
+		}
+	}
+
+	for _, def := range t.Defs {
+		fields := strings.Fields(def)
+		if len(fields) == 0 || !IsIdent(fields[0]) ||
+			strings.Contains(def, ";") ||
+			strings.Contains(def, "--") ||
+			strings.Contains(def, "/*") {
+			return fmt.Errorf(fnc+":%w", identErr(def))
+		}
+	}
+
+	return nil
+}
+
+// quoteDef returns attribute definition def with its name quoted.
+func quoteDef(def string) string {
+	return gDialect.quoteDef(def)
+}
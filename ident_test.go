@@ -0,0 +1,137 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for identifier validation.
+
+package livedb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type isIdentTest struct {
+	name string
+	//
+	ok bool
+}
+
+// TestIsIdent tests identifier validation.
+func TestIsIdent(t *testing.T) {
+
+	isIdentTests := []isIdentTest{
+		{"ttest", true},
+		{"_t1", true},
+		{strings.Repeat("t", 63), true},
+		{"", false},
+		{"1t", false},
+		{"Ttest", false},
+		{"t-test", false},
+		{"t test", false},
+		{"täst", false},
+		{`t"test`, false},
+		{"t`test", false},
+		{strings.Repeat("t", 64), false},
+	}
+
+	for i, v := range isIdentTests {
+		ok := IsIdent(v.name) // <------- ACTION
+		if ok != v.ok {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.ok, "got", ok)
+		}
+	}
+}
+
+type hostileTest struct {
+	name string
+	atts []string
+	defs []string
+	//
+	ok bool
+}
+
+// TestHostileNames tests that hostile names are refused
+// with an invalid identifier error.
+func TestHostileNames(t *testing.T) {
+
+	hostileTests := []hostileTest{
+		{tetab, teAtts, teDefs, true},
+		{"ttest;drop table ttest", teAtts, teDefs, false},
+		{"ttest--", teAtts, teDefs, false},
+		{"ttest/*", teAtts, teDefs, false},
+		{`ttest"`, teAtts, teDefs, false},
+		{strings.Repeat("t", 54), teAtts, teDefs, false}, // too long for index names
+		{tetab, []string{"str1", "str2 from ttestid --"}, teDefs, false},
+		{tetab, []string{"str1", "(select 1)"}, teDefs, false},
+		{tetab, teAtts, []string{"str1 varchar(10)", "str2 varchar(10));drop table ttestid"}, false},
+		{tetab, teAtts, []string{"str1 varchar(10) -- comment"}, false},
+		{tetab, teAtts, []string{"str1 varchar(10) /* comment */"}, false},
+		{tetab, teAtts, []string{"Str1 varchar(10)"}, false},
+	}
+
+	for i, v := range hostileTests {
+		tab := Table{Name: v.name, Atts: v.atts, Defs: v.defs, Scan: teScan}
+
+		err := tab.Create(nil) // <------- ACTION
+		if err == nil {
+			_, err = tab.ByTs(Now, nil) // <------- ACTION
+		}
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil && (!errors.Is(err, ErrIdent) || !errors.Is(err, ErrInvalid)):
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected invalid identifier, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestHostileColumns tests that hostile column names in
+// NameValues, filters and order options are refused.
+func TestHostileColumns(t *testing.T) {
+
+	type columnTest struct {
+		xs   []NameValue
+		opts []func(*Table)
+		//
+		ok bool
+	}
+	columnTests := []columnTest{
+		{[]NameValue{{"STR1", "x"}}, nil, true},
+		{[]NameValue{{"str1='x' or 1=1 or str1", "x"}}, nil, false},
+		{[]NameValue{{"str1", "x"}}, []func(*Table){WithFilter(Eq(`str1" or "1`, 1))}, false},
+		{[]NameValue{{"str1", "x"}}, []func(*Table){WithOrderBy("(select 1)")}, false},
+	}
+
+	for i, v := range columnTests {
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+		_, err := tab.ByTsAndXs(Now, v.xs, nil, v.opts...) // <------- ACTION
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil && !errors.Is(err, ErrInvalid):
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ErrInvalid, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
@@ -3,7 +3,7 @@
 // that can be found in the LICENSE file.
 
 // THIS FILE HAS BEEN GENERATED BY l10n using l10n.json.
-// ON 2021-08-24 10:35:06.526230056 +0200 CEST. DO NOT EDIT.
+// ON 2026-10-18 23:10:44.226909505 +0000 UTC . DO NOT EDIT.
 // CHANGES WILL DISAPPEAR AFTER NEXT RUN OF l10n.
 
 /*
@@ -86,6 +86,7 @@ type l10nVars struct {
 	Int1  int
 	Int2  int
 	Type  string
+	Typ2  string
 	Table string
 	Query string
 }
@@ -185,6 +186,13 @@ func L10nReplace(tmpl string, vars []struct {
 				return "", fmt.Errorf(fnc+":%w:", err)
 			}
 			allVars.Type = v
+		case "Typ2":
+			v, ok := pair.Value.(string)
+			if !ok {
+				err = errors.New("L10N:variable 'Typ2' should have type string but has " + fmt.Sprintf("%T", pair.Value) + " in template:\n'" + tmpl + "'\n")
+				return "", fmt.Errorf(fnc+":%w:", err)
+			}
+			allVars.Typ2 = v
 		case "Table":
 			v, ok := pair.Value.(string)
 			if !ok {
@@ -298,6 +306,16 @@ func init() {
    "Value": "ID-Tabelle wurde nicht geändert"
   }
  ],
+ "LIVEDB:WithAfter cannot be combined with WithOrderBy": [
+  {
+   "Lang": "en",
+   "Value": "WithAfter cannot be combined with WithOrderBy"
+  },
+  {
+   "Lang": "de",
+   "Value": "WithAfter kann nicht mit WithOrderBy kombiniert werden"
+  }
+ ],
  "LIVEDB:access needs at least database object": [
   {
    "Lang": "en",
@@ -308,6 +326,26 @@ func init() {
    "Value": "Zugriff braucht zumindest ein Datenbankobjekt"
   }
  ],
+ "LIVEDB:attribute {{.Name}} cannot be narrowed": [
+  {
+   "Lang": "en",
+   "Value": "attribute {{.Name}} cannot be narrowed"
+  },
+  {
+   "Lang": "de",
+   "Value": "Attribut {{.Name}} kann nicht verkleinert werden"
+  }
+ ],
+ "LIVEDB:attributes {{.Name}} of table {{.Nam2}} are not defined": [
+  {
+   "Lang": "en",
+   "Value": "attributes {{.Name}} of table {{.Nam2}} are not defined"
+  },
+  {
+   "Lang": "de",
+   "Value": "Attribute {{.Name}} der Tabelle {{.Nam2}} sind nicht definiert"
+  }
+ ],
  "LIVEDB:begin transaction failed": [
   {
    "Lang": "en",
@@ -328,6 +366,16 @@ func init() {
    "Value": "Änderung der Vergangenheit {{.Name}} nicht möglich"
   }
  ],
+ "LIVEDB:cannot scan {{.Type}} into time": [
+  {
+   "Lang": "en",
+   "Value": "cannot scan {{.Type}} into time"
+  },
+  {
+   "Lang": "de",
+   "Value": "{{.Type}} ist nicht als Zeit lesbar"
+  }
+ ],
  "LIVEDB:close database failed": [
   {
    "Lang": "en",
@@ -388,6 +436,26 @@ func init() {
    "Value": "Datenbank bereits geöffnet"
   }
  ],
+ "LIVEDB:empty filter {{.Name}}": [
+  {
+   "Lang": "en",
+   "Value": "empty filter {{.Name}}"
+  },
+  {
+   "Lang": "de",
+   "Value": "leerer Filter {{.Name}}"
+  }
+ ],
+ "LIVEDB:error altering table by:{{.Query}}": [
+  {
+   "Lang": "en",
+   "Value": "error altering table by:\n{{.Query}}\n"
+  },
+  {
+   "Lang": "de",
+   "Value": "Fehler beim Ändern der Tabelle mit:\n{{.Query}}\n"
+  }
+ ],
  "LIVEDB:error at rows.Next for query:{{.Query}}": [
   {
    "Lang": "en",
@@ -408,6 +476,16 @@ func init() {
    "Value": "Fehler bei scan(rows) in Abfrage:\n{{.Query}}\n"
   }
  ],
+ "LIVEDB:error closing rows": [
+  {
+   "Lang": "en",
+   "Value": "error closing rows"
+  },
+  {
+   "Lang": "de",
+   "Value": "Fehler beim Schließen der Zeilen"
+  }
+ ],
  "LIVEDB:error creating index by:{{.Query}}": [
   {
    "Lang": "en",
@@ -428,6 +506,16 @@ func init() {
    "Value": "Fehler beim create table:\n{{.Query}}\n"
   }
  ],
+ "LIVEDB:error dropping table by:{{.Query}}": [
+  {
+   "Lang": "en",
+   "Value": "error dropping table by:\n{{.Query}}\n"
+  },
+  {
+   "Lang": "de",
+   "Value": "Fehler beim Löschen der Tabelle mit:\n{{.Query}}\n"
+  }
+ ],
  "LIVEDB:error executing ID-table update": [
   {
    "Lang": "en",
@@ -508,6 +596,16 @@ func init() {
    "Value": "Fehler bei rows.Next"
   }
  ],
+ "LIVEDB:error renaming by:{{.Query}}": [
+  {
+   "Lang": "en",
+   "Value": "error renaming by:\n{{.Query}}\n"
+  },
+  {
+   "Lang": "de",
+   "Value": "Fehler beim Umbenennen mit:\n{{.Query}}\n"
+  }
+ ],
  "LIVEDB:error scanning row": [
   {
    "Lang": "en",
@@ -538,6 +636,26 @@ func init() {
    "Value": "Fehler beim Ermitteln desr zuletzt eingefügten keys"
   }
  ],
+ "LIVEDB:invalid identifier: {{.Name}}": [
+  {
+   "Lang": "en",
+   "Value": "invalid identifier: {{.Name}}"
+  },
+  {
+   "Lang": "de",
+   "Value": "ungültiger Bezeichner: {{.Name}}"
+  }
+ ],
+ "LIVEDB:invalid limit {{.Int}}": [
+  {
+   "Lang": "en",
+   "Value": "invalid limit {{.Int}}"
+  },
+  {
+   "Lang": "de",
+   "Value": "ungültiges Limit {{.Int}}"
+  }
+ ],
  "LIVEDB:no transaction to commit": [
   {
    "Lang": "en",
@@ -598,6 +716,16 @@ func init() {
    "Value": "nichts geändert"
   }
  ],
+ "LIVEDB:nullability of attribute {{.Name}} cannot be changed": [
+  {
+   "Lang": "en",
+   "Value": "nullability of attribute {{.Name}} cannot be changed"
+  },
+  {
+   "Lang": "de",
+   "Value": "Nullbarkeit von Attribut {{.Name}} kann nicht geändert werden"
+  }
+ ],
  "LIVEDB:open database failed": [
   {
    "Lang": "en",
@@ -668,6 +796,16 @@ func init() {
    "Value": "Tabellenname fehlt"
   }
  ],
+ "LIVEDB:table {{.Name}} exists already": [
+  {
+   "Lang": "en",
+   "Value": "table {{.Name}} exists already"
+  },
+  {
+   "Lang": "de",
+   "Value": "Tabelle {{.Name}} existiert bereits"
+  }
+ ],
  "LIVEDB:table {{.Name}} exists, table {{.Nam2}} is missing": [
   {
    "Lang": "en",
@@ -678,6 +816,16 @@ func init() {
    "Value": "Tabelle {{.Name}} existiert, Tabelle {{.Nam2}} fehlt"
   }
  ],
+ "LIVEDB:table {{.Name}} missing": [
+  {
+   "Lang": "en",
+   "Value": "table {{.Name}} missing"
+  },
+  {
+   "Lang": "de",
+   "Value": "Tabelle {{.Name}} fehlt"
+  }
+ ],
  "LIVEDB:timestamp {{.Name}} too short: {{.Tmsp}}, expected {{.Int}}+ characters": [
   {
    "Lang": "en",
@@ -688,6 +836,56 @@ func init() {
    "Value": "Zeitstempel {{.Name}} zu kurz: {{.Tmsp}}, {{.Int}}+ Zeichen erwartet"
   }
  ],
+ "LIVEDB:transaction retry canceled": [
+  {
+   "Lang": "en",
+   "Value": "transaction retry canceled"
+  },
+  {
+   "Lang": "de",
+   "Value": "Wiederholung der Transaktion abgebrochen"
+  }
+ ],
+ "LIVEDB:type of attribute {{.Name}} cannot be changed from {{.Type}} to {{.Typ2}}": [
+  {
+   "Lang": "en",
+   "Value": "type of attribute {{.Name}} cannot be changed from {{.Type}} to {{.Typ2}}"
+  },
+  {
+   "Lang": "de",
+   "Value": "Typ von Attribut {{.Name}} kann nicht von {{.Type}} in {{.Typ2}} geändert werden"
+  }
+ ],
+ "LIVEDB:unknown column {{.Name}}": [
+  {
+   "Lang": "en",
+   "Value": "unknown column {{.Name}}"
+  },
+  {
+   "Lang": "de",
+   "Value": "unbekannte Spalte {{.Name}}"
+  }
+ ],
+ "LIVEDB:unknown dialect {{.Name}}": [
+  {
+   "Lang": "en",
+   "Value": "unknown dialect {{.Name}}"
+  },
+  {
+   "Lang": "de",
+   "Value": "unbekannter Dialekt {{.Name}}"
+  }
+ ],
+ "LIVEDB:unknown order column {{.Name}}": [
+  {
+   "Lang": "en",
+   "Value": "unknown order column {{.Name}}"
+  },
+  {
+   "Lang": "de",
+   "Value": "unbekannte Sortierspalte {{.Name}}"
+  }
+ ],
  "LIVEDB:write access needs transaction object": [
   {
    "Lang": "en",
@@ -698,6 +896,16 @@ func init() {
    "Value": "Schreibzugriff braucht eine aktive Transaktion"
   }
  ],
+ "LIVEDB:write access refused in read-only transaction": [
+  {
+   "Lang": "en",
+   "Value": "write access refused in read-only transaction"
+  },
+  {
+   "Lang": "de",
+   "Value": "Schreibzugriff in einer Nur-Lese-Transaktion abgelehnt"
+  }
+ ],
  "LIVEDB:{{.Name}} missing": [
   {
    "Lang": "en",
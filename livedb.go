@@ -14,7 +14,7 @@
 package livedb
 
 import (
-	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -27,6 +27,104 @@ const pkg = "livedb"
 // Use it for non-standard read access (selects with joined tables, etc).
 var GDb *sql.DB
 
+// Querier is the common interface of *sql.DB, *sql.Tx, *sql.Conn and *Tx.
+// Livedb functions use it for all database access,
+// so any of them - or a wrapper (e.g. for tracing) - can be passed.
+//
+// A nil Querier means GDb for read access.
+// Write access needs a transaction object (or a connection
+// with an active transaction).
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// isNil reports whether q is nil, also if it is a typed nil.
+func isNil(q Querier) bool {
+	switch v := q.(type) {
+	case nil:
+		return true
+	case *sql.DB:
+		return v == nil
+	case *sql.Tx:
+		return v == nil
+	case *Tx:
+		return v == nil || v.Tx == nil
+	case *sql.Conn:
+		return v == nil
+	case ctxQuerier:
+		return isNil(v.q)
+	}
+	return false
+}
+
+// noTx reports whether q is surely no transaction: nil or a *sql.DB.
+func noTx(q Querier) bool {
+	_, q = unwrap(q)
+	_, ok := q.(*sql.DB)
+	return ok || isNil(q)
+}
+
+// querier returns q, or GDb if q is nil.
+func querier(q Querier) Querier {
+	if isNil(q) {
+		return GDb
+	}
+	return q
+}
+
+// ctxQuerier is a Querier bound to a context (see WithContext).
+type ctxQuerier struct {
+	ctx context.Context
+	q   Querier
+}
+
+func (c ctxQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return querier(c.q).ExecContext(ctx, query, args...)
+}
+
+func (c ctxQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return querier(c.q).QueryContext(ctx, query, args...)
+}
+
+// WithContext returns q bound to ctx. Livedb functions use ctx
+// for all database access with the returned Querier,
+// so canceling ctx or its deadline aborts their statements:
+//
+//	recs, err := t.ByTs(ts, livedb.WithContext(r.Context(), tx))
+//
+// q may be nil, GDb is used then. Without WithContext livedb functions
+// use the context of a *Tx (see BeginTx) or context.Background().
+func WithContext(ctx context.Context, q Querier) Querier {
+	return ctxQuerier{ctx: ctx, q: q}
+}
+
+// unwrap returns the context q is bound to and q without it.
+// A *Tx is bound to the context it has been begun with.
+func unwrap(q Querier) (context.Context, Querier) {
+	switch v := q.(type) {
+	case ctxQuerier:
+		return v.ctx, v.q
+	case *Tx:
+		if v != nil && v.ctx != nil {
+			return v.ctx, v
+		}
+	}
+	return context.Background(), q
+}
+
+// dbExec executes statement s using q.
+func dbExec(q Querier, s string, args ...interface{}) (sql.Result, error) {
+	ctx, q := unwrap(q)
+	return querier(q).ExecContext(ctx, s, args...)
+}
+
+// dbQuery executes query s using q.
+func dbQuery(q Querier, s string, args ...interface{}) (*sql.Rows, error) {
+	ctx, q := unwrap(q)
+	return querier(q).QueryContext(ctx, s, args...)
+}
+
 // Open opens the livedb database and assigns it to the global database object.
 func Open(openString string) error {
 	fnc := "Open"
@@ -83,6 +181,52 @@ func Begin() (tx *sql.Tx, err error) {
 	return tx, nil
 }
 
+// ReadOnlySnapshot is a preset for BeginTx: a read-only transaction
+// that sees a consistent snapshot of the database.
+var ReadOnlySnapshot = sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+// SerializableWrite is a preset for BeginTx: a writing transaction
+// with isolation level SERIALIZABLE.
+// (Consider RunInTx to retry such transactions.)
+var SerializableWrite = sql.TxOptions{Isolation: sql.LevelSerializable}
+
+// BeginTx starts a transaction with the given options
+// and returns a transaction object.
+//
+// Livedb functions use ctx for their statements with tx.
+// Write operations refuse read-only transactions.
+// End the transaction with Commit(tx.Tx) or Rollback(tx.Tx).
+func BeginTx(ctx context.Context, opts *sql.TxOptions) (tx *Tx, err error) {
+	fnc := "BeginTx"
+
+	tx, err = beginTx(ctx, GDb, opts)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return tx, nil
+}
+
+func beginTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions) (tx *Tx, err error) {
+	fnc := "beginTx"
+
+	if db == nil {
+		err = Err{Fix: "LIVEDB:access needs at least database object"}
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	sqlTx, err := db.BeginTx(ctx, txOptions(opts))
+	if err != nil {
+		e := Err{Fix: "LIVEDB:begin transaction failed"}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+	tx = &Tx{Tx: sqlTx, ctx: ctx, readOnly: opts != nil && opts.ReadOnly}
+
+	Log("begin transaction")
+
+	return tx, nil
+}
+
 // Commit ends a transaction and deletes the transaction object.
 func Commit(tx *sql.Tx) error {
 	fnc := "Commit"
@@ -124,15 +268,17 @@ func Rollback(tx *sql.Tx) error {
 }
 
 // Std consists of all the livedb standard attributes.
+//
+// JSON names are camel case; Ended and EndedBy are omitted if empty.
 type Std struct {
-	ID        int    // specifically typed ID may be used in some API
-	Begin     string // valid from and including timestamp
-	Until     string // valid until and excluding timestamp
-	Pkey      int    // primary key
-	Created   string // created timestamp
-	CreatedBy string // created by
-	Ended     string // terminated timestamp
-	EndedBy   string // terminated by
+	ID        int    `json:"id"`                // specifically typed ID may be used in some API
+	Begin     string `json:"begin"`             // valid from and including timestamp
+	Until     string `json:"until"`             // valid until and excluding timestamp
+	Pkey      int    `json:"pkey"`              // primary key
+	Created   string `json:"created"`           // created timestamp
+	CreatedBy string `json:"createdBy"`         // created by
+	Ended     string `json:"ended,omitempty"`   // terminated timestamp
+	EndedBy   string `json:"endedBy,omitempty"` // terminated by
 }
 
 // NOTE: Not every database supports date/time/timestamp data types (e.g. Sqlite).
@@ -159,10 +305,8 @@ type ScanFunc func(*sql.Rows) (Record, error)
 // ValsFunc is a function type.
 // It takes a struct that corresponds to an database object and returns
 // an interface slice containing values in the order the struct fields are defined.
-// An empty string indicates a NULL value.
-type ValsFunc func(interface{}) []string
-
-//type ValsFunc func(interface{}) []interface{} // problem: null
+// A nil value indicates a NULL value.
+type ValsFunc func(interface{}) []interface{}
 
 type Table struct {
 	Name string   // table name
@@ -172,29 +316,52 @@ type Table struct {
 	New  Record
 	Vals ValsFunc
 	Scan ScanFunc
+
+	Origin string // registered with the table, e.g. generator and its version
+
+	opts *readOptions // options of one read call, see withOptions
 }
 
-// Table.Create creates a livedb table with the correspondend ID-table (initialized).
+// Table.Create creates a livedb table with the correspondend ID-table (initialized)
+// and registers it in table livedb_tables (see Tables).
+// Nothing happens if both tables exist and are registered already.
+//
+// Where applications must not create tables, create them with the
+// statements of Table.DDL and MetaDDL beforehand; then Create only registers.
 //
-// NOTE: Table.Create works with transaction tx for Sqlite,
-// but not for Postgres - use nil there.
-func (t *Table) Create(tx *sql.Tx) error {
+// NOTE: Mysql commits a transaction implicitly on create table.
+func (t *Table) Create(q Querier) error {
 	fnc := "Table.Create"
 
-	err := t.createPrecs() // preconditions
+	err := t.createPrecs(q) // preconditions
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	err = readOnlyPrecs(q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
 
-	ok, err := t.exists(tx)
+	ok, err := t.exists(q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
 	if ok {
-		return nil // already created
+		ok, err = t.registered(q)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+		if ok {
+			return nil // already created
+		}
+	} else {
+		err = t.create(q)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
 	}
 
-	err = t.create(tx)
+	err = t.register(q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -202,10 +369,10 @@ func (t *Table) Create(tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) createPrecs() error {
+func (t *Table) createPrecs(q Querier) error {
 	fnc := "Table.createPrecs"
 
-	if GDb == nil {
+	if isNil(q) && GDb == nil {
 		err := Err{Fix: "LIVEDB:create needs database object"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -214,6 +381,10 @@ func (t *Table) createPrecs() error {
 		err := Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 
 	// allow "empty" tables
 // 	if len(t.Defs) < 1 {
@@ -233,25 +404,25 @@ func (t *Table) createPrecs() error {
 	return nil
 }
 
-func (t *Table) create(tx *sql.Tx) error {
+func (t *Table) create(q Querier) error {
 	fnc := "Table.create"
 
-	err := t.createTable(tx)
+	err := t.createTable(q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
 
-	err = t.createIDTable(tx)
+	err = t.createIDTable(q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
 
-	err = t.createIndexIDBegin(tx)
+	err = t.createIndexIDBegin(q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
 
-	err = t.createIndexIDUntil(tx)
+	err = t.createIndexIDUntil(q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -259,31 +430,14 @@ func (t *Table) create(tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) createTable(tx *sql.Tx) error {
+func (t *Table) createTable(q Querier) error {
 	fnc := "Table.createTable"
 
-	var buf bytes.Buffer
-	var put = buf.WriteString
-	var err error
-
-	put("create table " + t.Name + "(") // <======== create table
-	for _, def := range StdDefs {
-		put(def + ",")
-	}
-	for _, def := range t.Defs {
-		put(def + ",")
-	}
-
-	s := buf.String()
-	s = s[:len(s)-1] + ");" // replace last comma
+	s := gDialect.createTable(t) // <======== create table
 
 	Log("s:", s)
 
-	if tx != nil {
-		_, err = tx.Exec(s)
-	} else {
-		_, err = GDb.Exec(s)
-	}
+	_, err := dbExec(q, s)
 	if err != nil {
 		e := Err{
 			Fix: "LIVEDB:error creating table by:{{.Query}}",
@@ -300,27 +454,14 @@ func (t *Table) createTable(tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) createIDTable(tx *sql.Tx) error {
+func (t *Table) createIDTable(q Querier) error {
 	fnc := "Table.createIDTable"
 
-	var buf bytes.Buffer
-	var put = buf.WriteString
-	var err error
-
-	put("create table " + t.Name + "id(") // <======== create id-table
-	for _, def := range stdIDDefs {
-		put(def + ",")
-	}
-	s := buf.String()
-	s = s[:len(s)-1] + ");" // replace last comma
+	s := gDialect.createIDTable(t) // <======== create id-table
 
 	Log("s:", s)
 
-	if tx != nil {
-		_, err = tx.Exec(s)
-	} else {
-		_, err = GDb.Exec(s)
-	}
+	_, err := dbExec(q, s)
 	if err != nil {
 		e := Err{
 			Fix: "LIVEDB:error creating table by:{{.Query}}",
@@ -337,24 +478,14 @@ func (t *Table) createIDTable(tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) createIndexIDBegin(tx *sql.Tx) error {
+func (t *Table) createIndexIDBegin(q Querier) error {
 	fnc := "Table.createIndexIDBegin"
 
-	var buf bytes.Buffer
-	var put = buf.WriteString
-	var err error
-
-	put("create unique index " + t.Name + "idxidbegin on " + t.Name + " (id, begin);")
-
-	s := buf.String()
+	s := gDialect.createIndexIDBegin(t)
 
 	Log("s:", s)
 
-	if tx != nil {
-		_, err = tx.Exec(s)
-	} else {
-		_, err = GDb.Exec(s)
-	}
+	_, err := dbExec(q, s)
 	if err != nil {
 		e := Err{
 			Fix: "LIVEDB:error creating index by:{{.Query}}",
@@ -371,24 +502,14 @@ func (t *Table) createIndexIDBegin(tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) createIndexIDUntil(tx *sql.Tx) error {
+func (t *Table) createIndexIDUntil(q Querier) error {
 	fnc := "Table.createIndexIDUntil"
 
-	var buf bytes.Buffer
-	var put = buf.WriteString
-	var err error
-
-	put("create index " + t.Name + "idxiduntil on " + t.Name + " (id, until);")
-
-	s := buf.String()
+	s := gDialect.createIndexIDUntil(t)
 
 	Log("s:", s)
 
-	if tx != nil {
-		_, err = tx.Exec(s)
-	} else {
-		_, err = GDb.Exec(s)
-	}
+	_, err := dbExec(q, s)
 	if err != nil {
 		e := Err{
 			Fix: "LIVEDB:error creating index by:{{.Query}}",
@@ -404,56 +525,3 @@ func (t *Table) createIndexIDUntil(tx *sql.Tx) error {
 
 	return nil
 }
-
-func (t *Table) exists(tx *sql.Tx) (bool, error) {
-	fnc := "Table.exists"
-
-	var err1, err2 error
-
-	s := "select count(*) from " + t.Name + ";"
-	Log("s:", s)
-	if tx != nil {
-		_, err1 = tx.Query(s)
-	} else {
-		_, err1 = GDb.Query(s)
-	}
-
-	s = "select count(*) from " + t.Name + "id;"
-	Log("s:", s)
-	if tx != nil {
-		_, err2 = tx.Query(s)
-	} else {
-		_, err2 = GDb.Query(s)
-	}
-
-	switch {
-	case err1 == nil && err2 != nil:
-		e := Err{
-			Fix: "LIVEDB:table {{.Name}} exists, table {{.Nam2}} is missing",
-			Var: []struct {
-				Name  string
-				Value interface{}
-			}{
-				{"Name", t.Name},
-				{"Nam2", t.Name + "id"},
-			},
-		}
-		return false, fmt.Errorf(fnc+":%w:"+err2.Error(), e)
-	case err2 == nil && err1 != nil:
-		e := Err{
-			Fix: "LIVEDB:table {{.Name}} exists, table {{.Nam2}} is missing",
-			Var: []struct {
-				Name  string
-				Value interface{}
-			}{
-				{"Name", t.Name + "id"},
-				{"Nam2", t.Name},
-			},
-		}
-		return false, fmt.Errorf(fnc+":%w:"+err1.Error(), e)
-	case err2 == nil && err1 == nil:
-		return true, nil
-	default:
-		return false, nil
-	}
-}
@@ -11,9 +11,11 @@ package livedb
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"regexp"
 
-	_ "github.com/go-sql-driver/mysql" // Mysql db
+	"github.com/go-sql-driver/mysql" // Mysql db
 
 	. "github.com/hwheinzen/stringl10n/mistake"
 )
@@ -35,16 +37,11 @@ var StdAtts = []string{
 	"endedby",
 }
 
-var StdDefs = []string{
-	"id integer not null",
-	"begin varchar(26) not null",
-	"until varchar(26)",
-	"pkey integer auto_increment primary key",
-	"created varchar(26) not null",
-	"createdby varchar(50) not null",
-	"ended varchar(26)", // 'terminated' is reserved word for MariaDB/MySQL
-	"endedby varchar(50)",
-}
+// gDialect contains the SQL specifics creating tables (see ddl.go).
+var gDialect = mysqlDialect
+
+// StdDefs are the definitions of the standard attributes.
+var StdDefs = gDialect.stdDefs
 
 var stdIDAtts = []string{
 	"id",
@@ -53,13 +50,6 @@ var stdIDAtts = []string{
 	"usedby",
 }
 
-var stdIDDefs = []string{
-	"id integer auto_increment primary key",
-	"created varchar(26) not null",
-	"createdby varchar(50) not null",
-	"usedby varchar(50)",
-}
-
 // FormatTmsp returns a string containing the SQL formatting
 // of a timestamp attribute in Mysql syntax.
 func FormatTmsp(num int) string {
@@ -97,7 +87,7 @@ func FormatNull() string {
 	return "NULL"
 }
 
-func (t *Table) insertedKey(tx *sql.Tx, r sql.Result) (int, error) {
+func (t *Table) insertedKey(q Querier, r sql.Result) (int, error) {
 	fnc := "Table.insertedKey"
 
 	n, err := r.LastInsertId() // from autoincrement pkey
@@ -109,7 +99,7 @@ func (t *Table) insertedKey(tx *sql.Tx, r sql.Result) (int, error) {
 	return int(n), nil
 }
 
-func (t *Table) insertedID(tx *sql.Tx, r sql.Result) (int, error) {
+func (t *Table) insertedID(q Querier, r sql.Result) (int, error) {
 	fnc := "Table.insertedKey"
 
 	n, err := r.LastInsertId() // from autoincrement pkey
@@ -120,3 +110,63 @@ func (t *Table) insertedID(tx *sql.Tx, r sql.Result) (int, error) {
 
 	return int(n), nil
 }
+
+// retryable reports whether err indicates that the transaction
+// could not be serialized and may succeed if tried again.
+//
+// Livedb errors carry the driver's message only,
+// so the message is checked too.
+func retryable(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return myErr.Number == 1213 || myErr.Number == 1205 // deadlock, lock wait timeout
+	}
+	return lockErr.MatchString(err.Error())
+}
+
+// lockErr matches the messages of deadlocks and lock wait timeouts,
+// e.g. "Error 1213: ..." or "Error 1213 (40001): ...".
+var lockErr = regexp.MustCompile(`Error 12(13|05)[: ]`)
+
+// txOptions returns the transaction options to be used with Mysql.
+// Mysql supports them all.
+func txOptions(opts *sql.TxOptions) *sql.TxOptions {
+	return opts
+}
+
+// quote returns identifier name quoted for Mysql.
+func quote(name string) string {
+	return gDialect.quote(name)
+}
+
+// Catalog queries for Mysql (argument: table name).
+const (
+	sqlTableExists = "select count(*) from information_schema.tables" +
+		" where table_schema=database() and table_name=?;"
+	sqlColumns = "select column_name,data_type,coalesce(character_maximum_length,0),is_nullable" +
+		" from information_schema.columns" +
+		" where table_schema=database() and table_name=? order by ordinal_position;"
+	sqlIndexes = "select index_name,1-non_unique,column_name" +
+		" from information_schema.statistics" +
+		" where table_schema=database() and table_name=?" +
+		" order by index_name,seq_in_index;"
+)
+
+// widenColumn returns the statement widening a character column
+// of table to definition def.
+func widenColumn(table, def string) string {
+	return "alter table " + quote(table) + " modify column " + quoteDef(def) + ";"
+}
+
+// renameIndex returns the statement renaming index old of table to neu.
+func renameIndex(table, old, neu string) string {
+	return "alter table " + quote(table) + " rename index " + quote(old) + " to " + quote(neu) + ";"
+}
+
+// renameSequences returns the statements renaming the sequences
+// of livedb table old to neu.
+//
+// Mysql keeps auto_increment counters by table - nothing to do.
+func renameSequences(old, neu string) []string {
+	return nil
+}
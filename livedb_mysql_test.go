@@ -6,7 +6,11 @@
 
 package livedb
 
-import "log"
+import (
+	"log"
+
+	"github.com/go-sql-driver/mysql"
+)
 
 const gDbOpen = "livedb:@/testdb"
 //const gDbOpen = "hawe:@/TESTdb"
@@ -32,7 +36,7 @@ func removeDb() error {
 	}
 
 	t := Table{Name: tetab}
-	ok, err := t.exists(nil)
+	ok, err := t.Exists(nil)
 	if err != nil {
 		err = translate(err, lang) // ******** l10n ********
 		log.Fatal(err)
@@ -109,4 +113,22 @@ var moveBeginTests = []moveBeginTest{
 	{"2100-01-01 00:00:00.000000", "0817String", 44, 0, 100, "2100-01-01 00:00:00.000000", true}, // OK ==> 1 record
 	{"2100-01-01 00:00:00.000000", "0818String", 45, 2, 100, "2100-01-01 00:00:00.000000", true}, // OK ==> 3 records
 	{"2100-01-01 00:00:00.000000", "0819String", 46, 2, 100, "2200-01-01 00:00:00.000000", true}, // OK ==> 3 records
+	{"2100-01-01 00:00:00.000000", "0820String", 47, 2, 100, "2350-06-06 00:00:00.000000", true}, // OK ==> 3 records, open one moved
+}
+
+var nullSteps = []nullStep{
+	{"2100-01-01 00:00:00.000000", false}, // start ==> "" and 0
+	{"2100-01-01 00:00:00.000000", true},  // change at begin ==> NULL
+	{"2100-01-01 00:00:00.000000", false}, // change at begin ==> "" and 0
+	{"2200-01-01 00:00:00.000000", true},  // change ==> new record with NULL
+	{"2300-01-01 00:00:00.000000", false}, // change ==> new record with "" and 0
+}
+
+var retryableErr = &mysql.MySQLError{Number: 1213} // deadlock
+
+// retryableMsgs are driver messages as wrapped into livedb errors.
+var retryableMsgs = []string{
+	"Error 1213: Deadlock found when trying to get lock; try restarting transaction",
+	"Error 1213 (40001): Deadlock found when trying to get lock; try restarting transaction",
+	"Error 1205 (HY000): Lock wait timeout exceeded; try restarting transaction",
 }
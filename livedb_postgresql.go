@@ -11,9 +11,11 @@ package livedb
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
-	_ "github.com/lib/pq" // Postgres db
+	"github.com/lib/pq" // Postgres db
 
 	. "github.com/hwheinzen/stringl10n/mistake"
 )
@@ -35,16 +37,11 @@ var StdAtts = []string{
 	"endedby",
 }
 
-var StdDefs = []string{
-	"id integer not null",
-	"begin varchar(26) not null",
-	"until varchar(26)",
-	"pkey serial",
-	"created varchar(26) not null",
-	"createdby varchar(50) not null",
-	"ended varchar(26)", // 'terminated' is reserved word for MariaDB/MySQL
-	"endedby varchar(50)",
-}
+// gDialect contains the SQL specifics creating tables (see ddl.go).
+var gDialect = postgresqlDialect
+
+// StdDefs are the definitions of the standard attributes.
+var StdDefs = gDialect.stdDefs
 
 var stdIDAtts = []string{
 	"id",
@@ -53,13 +50,6 @@ var stdIDAtts = []string{
 	"usedby",
 }
 
-var stdIDDefs = []string{
-	`"id" serial`,
-	"created varchar(26) not null",
-	"createdby varchar(50) not null",
-	"usedby varchar(50)",
-}
-
 // FormatTmsp returns a string containing the SQL formatting
 // of a timestamp attribute in PostgreSQL syntax.
 func FormatTmsp(num int) string {
@@ -101,20 +91,16 @@ func FormatNull() string {
 	return "NULL"
 }
 
-func (t *Table) insertedKey(tx *sql.Tx, r sql.Result) (key int, err error) {
+func (t *Table) insertedKey(q Querier, r sql.Result) (key int, err error) {
 	fnc := "Table.insertedKey"
 
 	rows := &sql.Rows{}
 
-	s := "select last_value from " + t.Name + "_pkey_seq;"
+	s := "select last_value from " + quote(t.Name+"_pkey_seq") + ";"
 
 	Log("s:", s)
 
-	if tx != nil {
-		rows, err = tx.Query(s)
-	} else {
-		rows, err = GDb.Query(s)
-	}
+	rows, err = dbQuery(q, s)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:select last inserted key failed"}
 		return 0, fmt.Errorf(fnc+":%w:"+err.Error(), e)
@@ -137,20 +123,16 @@ func (t *Table) insertedKey(tx *sql.Tx, r sql.Result) (key int, err error) {
 	return key, nil
 }
 
-func (t *Table) insertedID(tx *sql.Tx, r sql.Result) (n int, err error) {
+func (t *Table) insertedID(q Querier, r sql.Result) (n int, err error) {
 	fnc := "Table.insertedID"
 
 	rows := &sql.Rows{}
 
-	s := "select last_value from " + t.Name + "ID_id_seq;"
+	s := "select last_value from " + quote(t.Name+"id_id_seq") + ";"
 
 	Log("s:", s)
 
-	if tx != nil {
-		rows, err = tx.Query(s)
-	} else {
-		rows, err = GDb.Query(s)
-	}
+	rows, err = dbQuery(q, s)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:select last inserted id failed"}
 		return 0, fmt.Errorf(fnc+":%w:"+err.Error(), e)
@@ -172,3 +154,67 @@ func (t *Table) insertedID(tx *sql.Tx, r sql.Result) (n int, err error) {
 
 	return n, nil
 }
+
+// retryable reports whether err indicates that the transaction
+// could not be serialized and may succeed if tried again.
+//
+// Livedb errors carry the driver's message only,
+// so the message is checked too.
+func retryable(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "40001" || pqErr.Code == "40P01" // serialization_failure, deadlock_detected
+	}
+	s := err.Error()
+	return strings.Contains(s, "could not serialize access") ||
+		strings.Contains(s, "deadlock detected")
+}
+
+// txOptions returns the transaction options to be used with PostgreSQL.
+// PostgreSQL supports them all.
+func txOptions(opts *sql.TxOptions) *sql.TxOptions {
+	return opts
+}
+
+// quote returns identifier name quoted for Postgres.
+func quote(name string) string {
+	return gDialect.quote(name)
+}
+
+// Catalog queries for Postgres (argument: table name).
+const (
+	sqlTableExists = "select count(*) from information_schema.tables" +
+		" where table_schema=current_schema() and table_name=$1;"
+	sqlColumns = "select column_name,data_type,coalesce(character_maximum_length,0),is_nullable" +
+		" from information_schema.columns" +
+		" where table_schema=current_schema() and table_name=$1 order by ordinal_position;"
+	sqlIndexes = "select i.relname,case when x.indisunique then 1 else 0 end,a.attname" +
+		" from pg_index x" +
+		" join pg_class t on t.oid=x.indrelid" +
+		" join pg_class i on i.oid=x.indexrelid" +
+		" join pg_attribute a on a.attrelid=t.oid and a.attnum=any(x.indkey)" +
+		" where t.relname=$1 and t.relnamespace=to_regnamespace(current_schema())" +
+		" order by i.relname,array_position(x.indkey::int2[],a.attnum);"
+)
+
+// widenColumn returns the statement widening a character column
+// of table to definition def.
+func widenColumn(table, def string) string {
+	typ, _ := parseDef(def)
+	return "alter table " + quote(table) +
+		" alter column " + quote(strings.Fields(def)[0]) + " type " + typ + ";"
+}
+
+// renameIndex returns the statement renaming index old of table to neu.
+func renameIndex(table, old, neu string) string {
+	return "alter index " + quote(old) + " rename to " + quote(neu) + ";"
+}
+
+// renameSequences returns the statements renaming the sequences
+// of livedb table old to neu (see insertedKey, insertedID).
+func renameSequences(old, neu string) []string {
+	return []string{
+		"alter sequence " + quote(old+"_pkey_seq") + " rename to " + quote(neu+"_pkey_seq") + ";",
+		"alter sequence " + quote(old+"id_id_seq") + " rename to " + quote(neu+"id_id_seq") + ";",
+	}
+}
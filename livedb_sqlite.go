@@ -14,6 +14,7 @@ package livedb
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
 	_ "rsc.io/sqlite"
 
@@ -38,16 +39,11 @@ var StdAtts = []string{
 	"endedby",
 }
 
-var StdDefs = []string{
-	"id integer not null",
-	"begin varchar(26) not null",
-	"until varchar(26)",
-	"pkey integer primary key autoincrement",
-	"created varchar(26) not null",
-	"createdby varchar(50) not null",
-	"ended varchar(26)", // 'terminated' is reserved word for MariaDB/MySQL
-	"endedby varchar(50)",
-}
+// gDialect contains the SQL specifics creating tables (see ddl.go).
+var gDialect = sqliteDialect
+
+// StdDefs are the definitions of the standard attributes.
+var StdDefs = gDialect.stdDefs
 
 var stdIDAtts = []string{
 	"id",
@@ -56,13 +52,6 @@ var stdIDAtts = []string{
 	"usedby",
 }
 
-var stdIDDefs = []string{
-	"id integer primary key autoincrement",
-	"created varchar(26) not null",
-	"createdby varchar(50) not null",
-	"usedby varchar(50)",
-}
-
 // FormatTmsp returns a string containing the SQL formatting
 // of a timestamp attribute in Sqlite syntax.
 func FormatTmsp(num int) string {
@@ -100,7 +89,7 @@ func FormatNull() string {
 	return "NULL"
 }
 
-func (t *Table) insertedKey(tx *sql.Tx, r sql.Result) (int, error) {
+func (t *Table) insertedKey(q Querier, r sql.Result) (int, error) {
 	fnc := "Table.insertedKey"
 
 	n, err := r.LastInsertId() // from autoincrement pkey
@@ -111,7 +100,7 @@ func (t *Table) insertedKey(tx *sql.Tx, r sql.Result) (int, error) {
 	return int(n), nil
 }
 
-func (t *Table) insertedID(tx *sql.Tx, r sql.Result) (int, error) {
+func (t *Table) insertedID(q Querier, r sql.Result) (int, error) {
 	fnc := "Table.insertedKey"
 
 	n, err := r.LastInsertId() // from autoincrement pkey
@@ -121,3 +110,61 @@ func (t *Table) insertedID(tx *sql.Tx, r sql.Result) (int, error) {
 	}
 	return int(n), nil
 }
+
+// retryable reports whether err indicates that the transaction
+// could not be serialized and may succeed if tried again.
+//
+// The Sqlite driver reports errors as text only.
+func retryable(err error) bool {
+	s := err.Error()
+	return strings.Contains(s, "database is locked") ||
+		strings.Contains(s, "database table is locked") ||
+		strings.Contains(s, "The database file is locked") ||
+		strings.Contains(s, "A table in the database is locked")
+}
+
+// txOptions returns the transaction options to be used with Sqlite.
+//
+// The Sqlite driver knows no options. Sqlite transactions are serializable
+// anyway, and livedb refuses write access in read-only transactions itself.
+func txOptions(opts *sql.TxOptions) *sql.TxOptions {
+	return nil
+}
+
+// quote returns identifier name quoted for Sqlite.
+func quote(name string) string {
+	return gDialect.quote(name)
+}
+
+// Catalog queries for Sqlite (argument: table name).
+const (
+	sqlTableExists = "select count(*) from sqlite_master where type='table' and name=?;"
+	sqlColumns     = "select name,type,0,case \"notnull\" when 0 then 'YES' else 'NO' end" +
+		" from pragma_table_info(?) order by cid;"
+	sqlIndexes = "select il.name,il.\"unique\",ii.name" +
+		" from pragma_index_list(?) il, pragma_index_info(il.name) ii" +
+		" order by il.name,ii.seqno;"
+)
+
+// widenColumn returns the statement widening a character column
+// of table to definition def.
+//
+// Sqlite does not enforce lengths of character types - nothing to do.
+func widenColumn(table, def string) string {
+	return ""
+}
+
+// renameIndex returns the statement renaming index old of table to neu.
+//
+// Sqlite cannot rename indexes - they have to be recreated.
+func renameIndex(table, old, neu string) string {
+	return ""
+}
+
+// renameSequences returns the statements renaming the sequences
+// of livedb table old to neu.
+//
+// Sqlite keeps autoincrement counters by table - nothing to do.
+func renameSequences(old, neu string) []string {
+	return nil
+}
@@ -10,7 +10,10 @@
 
 package livedb
 
-import "os/exec"
+import (
+	"errors"
+	"os/exec"
+)
 
 const gDbOpen = "testdb"
 
@@ -79,4 +82,21 @@ var moveBeginTests = []moveBeginTest{
 	{"2100-01-01 00:00:00.000", "0817String", 44, 0, 100, "2100-01-01 00:00:00.000", true}, // OK ==> 1 record
 	{"2100-01-01 00:00:00.000", "0818String", 45, 2, 100, "2100-01-01 00:00:00.000", true}, // OK ==> 3 records
 	{"2100-01-01 00:00:00.000", "0819String", 46, 2, 100, "2200-01-01 00:00:00.000", true}, // OK ==> 3 record
+	{"2100-01-01 00:00:00.000", "0820String", 47, 2, 100, "2350-06-06 00:00:00.000", true}, // OK ==> 3 records, open one moved
+}
+
+var nullSteps = []nullStep{
+	{"2100-01-01 00:00:00.000", false}, // start ==> "" and 0
+	{"2100-01-01 00:00:00.000", true},  // change at begin ==> NULL
+	{"2100-01-01 00:00:00.000", false}, // change at begin ==> "" and 0
+	{"2200-01-01 00:00:00.000", true},  // change ==> new record with NULL
+	{"2300-01-01 00:00:00.000", false}, // change ==> new record with "" and 0
+}
+
+var retryableErr = errors.New("The database file is locked: database is locked")
+
+// retryableMsgs are driver messages as wrapped into livedb errors.
+var retryableMsgs = []string{
+	"The database file is locked: database is locked",
+	"A table in the database is locked: database table is locked",
 }
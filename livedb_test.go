@@ -7,7 +7,9 @@
 package livedb
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"testing"
@@ -137,15 +139,21 @@ func teScan(rows *sql.Rows) (Record, error) {
 	return Record{Std: std, Idv: te}, nil
 }
 
-func teVals(in interface{}) []string {
+func teVals(in interface{}) []interface{} {
 	//	fnc := "teVals"
 
 	te := in.(Te)
 
-	vals := make([]string, len(teAtts))
-	vals[0] = te.str1
-	vals[1] = te.str2
-	vals[2] = fmt.Sprintf("%0.d", te.num) // "" indicates NULL
+	vals := make([]interface{}, len(teAtts)) // nil indicates NULL
+	if te.str1 != "" {
+		vals[0] = te.str1
+	}
+	if te.str2 != "" {
+		vals[1] = te.str2
+	}
+	if te.num != 0 {
+		vals[2] = te.num
+	}
 
 	return vals
 }
@@ -908,3 +916,102 @@ func TestMoveBegin(t *testing.T) {
 		}
 	}
 }
+
+// TestQuerier tests access using different kinds of Querier.
+func TestQuerier(t *testing.T) {
+
+	creator := "TestQuerier"
+
+	conn, err := GDb.Conn(context.Background()) // pinned connection
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer func() {
+		e := Commit(tx) // end transaction
+		if e != nil {
+			e = translate(e, lang) // ******** l10n ********
+			t.Log(e)
+		}
+	}()
+
+	var nilTx *sql.Tx
+
+	type querierTest struct {
+		q     Querier
+		write bool
+		//
+		ok bool
+	}
+	querierTests := []querierTest{
+		{nil, false, true},   // GDb
+		{nilTx, false, true}, // typed nil -> GDb
+		{GDb, false, true},
+		{conn, false, true},
+		{tx, false, true},
+		{tx, true, true},
+		{nil, true, false},   // no transaction
+		{nilTx, true, false}, // no transaction
+		{GDb, true, false},   // no transaction
+	}
+
+	for i, v := range querierTests {
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+		if v.write {
+			_, err = tab.NewID(creator, v.q) // <------- ACTION
+		} else {
+			_, err = tab.ByTs(Now, v.q) // <------- ACTION
+		}
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestStdJSON tests the JSON names of the standard attributes.
+func TestStdJSON(t *testing.T) {
+
+	type stdJSONTest struct {
+		in Std
+		//
+		want string
+	}
+	stdJSONTests := []stdJSONTest{
+		{
+			Std{ID: 1, Begin: "2021-01-01", Until: "9999-12-31", Pkey: 2, Created: "2021-01-01", CreatedBy: "me"},
+			`{"id":1,"begin":"2021-01-01","until":"9999-12-31","pkey":2,"created":"2021-01-01","createdBy":"me"}`,
+		},
+		{
+			Std{ID: 1, Ended: "2021-02-01", EndedBy: "you"},
+			`{"id":1,"begin":"","until":"","pkey":0,"created":"","createdBy":"","ended":"2021-02-01","endedBy":"you"}`,
+		},
+	}
+
+	for i, v := range stdJSONTests {
+		out, err := json.Marshal(v.in) // <------- ACTION
+		switch {
+		case err != nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case string(out) != v.want:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.want, "got", string(out))
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
@@ -0,0 +1,453 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// migrationsTab is the livedb metadata table recording applied migrations.
+const migrationsTab = "livedb_migrations"
+
+var migrationsDefs = []string{
+	"tabname varchar(63) not null",
+	"version integer not null",
+	"applied varchar(26) not null",
+	"appliedby varchar(50) not null",
+	"primary key (tabname, version)",
+}
+
+// Table.Migrate brings an existing livedb table in line with t.Defs:
+//   - attributes in t.Defs missing in the table are added,
+//   - attributes in the table missing in t.Defs are dropped with dropMissing;
+//     without dropMissing they are reported as error,
+//   - character attributes with a greater length in t.Defs are widened.
+//
+// Other differences cannot be migrated and are reported as error:
+// narrowed attributes, changed types and changed nullability.
+// Precision and scale of numeric types are not compared.
+//
+// Migrations are numbered by version and recorded in table livedb_migrations;
+// the table is registered anew in livedb_tables (see Tables).
+// Nothing happens if version or a higher version has been applied already.
+//
+// Table.Migrate returns the statements needed.
+// With dryRun they are returned only; otherwise they are executed,
+// which needs a transaction object.
+//
+// NOTE: Mysql commits a transaction implicitly on alter table.
+func (t *Table) Migrate(version int, creator string, dryRun, dropMissing bool, q Querier) ([]string, error) {
+	fnc := "Table.Migrate"
+
+	err := t.migratePrecs(version, creator, dryRun, q) // preconditions
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	ok, err := t.exists(q)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+	if !ok {
+		err := Err{
+			Fix: "LIVEDB:table {{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", t.Name},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	applied, err := t.appliedVersion(q)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+	if version <= applied {
+		return nil, nil // nothing to do
+	}
+
+	stmts, err := t.migrations(dropMissing, q)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	if dryRun {
+		return stmts, nil
+	}
+
+	for _, s := range stmts {
+		Log("s:", s)
+
+		_, err = dbExec(q, s)
+		if err != nil {
+			e := Err{
+				Fix: "LIVEDB:error altering table by:{{.Query}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Query", s},
+				},
+			}
+			return stmts, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+	}
+
+	err = t.recordVersion(version, creator, q)
+	if err != nil {
+		return stmts, fmt.Errorf(fnc+":%w", err)
+	}
+
+	err = t.register(q)
+	if err != nil {
+		return stmts, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return stmts, nil
+}
+
+func (t *Table) migratePrecs(version int, creator string, dryRun bool, q Querier) error {
+	fnc := "Table.migratePrecs"
+
+	if dryRun {
+		if isNil(q) && GDb == nil {
+			err := Err{Fix: "LIVEDB:access needs at least database object"}
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	} else {
+		err := writePrecs(Now, creator, q)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	if version <= 0 {
+		err := Err{
+			Fix: "LIVEDB:{{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "version"},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if t.Name == "" {
+		err := Err{Fix: "LIVEDB:table name missing"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+// migrations compares t.Defs with the columns of the table
+// and returns the statements needed. Columns missing in t.Defs
+// are dropped with dropMissing, otherwise they are an error.
+func (t *Table) migrations(dropMissing bool, q Querier) ([]string, error) {
+	fnc := "Table.migrations"
+
+	cols, err := columns(t.Name, q)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	var stmts []string
+	alter := "alter table " + quote(t.Name)
+
+	defined := make(map[string]bool)
+	for _, def := range t.Defs {
+		name := strings.Fields(def)[0] // see namesPrecs
+		defined[name] = true
+
+		var col *Column
+		for i := range cols {
+			if cols[i].Name == name {
+				col = &cols[i]
+				break
+			}
+		}
+
+		if col == nil { // add
+			stmts = append(stmts, alter+" add column "+quoteDef(def)+";")
+			continue
+		}
+
+		typ, nullable := parseDef(def)
+		base, length := splitType(typ, 0)
+		if canonicalType(base) != canonicalType(col.Type) {
+			err := Err{
+				Fix: "LIVEDB:type of attribute {{.Name}} cannot be changed from {{.Type}} to {{.Typ2}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Name", name},
+					{"Type", col.Type},
+					{"Typ2", typ},
+				},
+			}
+			return nil, fmt.Errorf(fnc+":%w", err)
+		}
+		if nullable != col.Nullable {
+			err := Err{
+				Fix: "LIVEDB:nullability of attribute {{.Name}} cannot be changed",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Name", name},
+				},
+			}
+			return nil, fmt.Errorf(fnc+":%w", err)
+		}
+
+		switch {
+		case length == col.Length:
+		case length == 0 || col.Length == 0: // e.g. varchar(10) -> text
+			err := Err{
+				Fix: "LIVEDB:type of attribute {{.Name}} cannot be changed from {{.Type}} to {{.Typ2}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Name", name},
+					{"Type", fmt.Sprintf("%s(%d)", col.Type, col.Length)},
+					{"Typ2", typ},
+				},
+			}
+			return nil, fmt.Errorf(fnc+":%w", err)
+		case length < col.Length:
+			err := Err{
+				Fix: "LIVEDB:attribute {{.Name}} cannot be narrowed",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Name", name},
+				},
+			}
+			return nil, fmt.Errorf(fnc+":%w", err)
+		default: // widen
+			s := widenColumn(t.Name, def)
+			if s != "" {
+				stmts = append(stmts, s)
+			}
+		}
+	}
+
+	var missing []string
+	for _, col := range cols { // drop
+		if defined[col.Name] {
+			continue
+		}
+		std := false
+		for _, att := range StdAtts {
+			if col.Name == att {
+				std = true
+				break
+			}
+		}
+		if !std {
+			missing = append(missing, col.Name)
+			stmts = append(stmts, alter+" drop column "+quote(col.Name)+";")
+		}
+	}
+	if len(missing) > 0 && !dropMissing {
+		err := Err{
+			Fix: "LIVEDB:attributes {{.Name}} of table {{.Nam2}} are not defined",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", strings.Join(missing, ",")},
+				{"Nam2", t.Name},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return stmts, nil
+}
+
+// parseDef returns the complete type clause of attribute definition def,
+// e.g. "character varying(50)" of "name character varying (50) not null",
+// and whether the attribute is nullable.
+func parseDef(def string) (typ string, nullable bool) {
+	fields := strings.Fields(def)[1:] // without name
+
+	var typFields []string
+	depth := 0
+	for len(fields) > 0 {
+		f := fields[0]
+		if depth == 0 && constraintWords[strings.ToLower(f)] {
+			break
+		}
+		depth += strings.Count(f, "(") - strings.Count(f, ")")
+		typFields = append(typFields, f)
+		fields = fields[1:]
+	}
+	typ = strings.ToLower(strings.Join(typFields, " "))
+	typ = strings.ReplaceAll(typ, " (", "(")
+
+	rest := " " + strings.ToLower(strings.Join(fields, " ")) + " "
+	nullable = !strings.Contains(rest, " not null ") &&
+		!strings.Contains(rest, " primary key ")
+
+	return typ, nullable
+}
+
+// constraintWords end the type clause of an attribute definition.
+var constraintWords = map[string]bool{
+	"not": true, "null": true, "default": true, "primary": true,
+	"unique": true, "check": true, "references": true, "constraint": true,
+	"collate": true, "generated": true, "auto_increment": true,
+}
+
+// canonicalType returns type typ (without length) in a form
+// that is the same for the names databases use for it,
+// e.g. "varchar" for "character varying".
+func canonicalType(typ string) string {
+	typ = strings.Join(strings.Fields(strings.ToLower(typ)), " ")
+	if i := strings.Index(typ, "("); i >= 0 { // e.g. decimal(10,2)
+		typ = strings.TrimSpace(typ[:i])
+	}
+	if c, ok := typeSynonyms[typ]; ok {
+		return c
+	}
+	return typ
+}
+
+// typeSynonyms maps type names to canonical ones.
+var typeSynonyms = map[string]string{
+	"character varying":           "varchar",
+	"char varying":                "varchar",
+	"character":                   "char",
+	"int":                         "integer",
+	"int4":                        "integer",
+	"serial":                      "integer",
+	"int8":                        "bigint",
+	"bigserial":                   "bigint",
+	"int2":                        "smallint",
+	"bool":                        "boolean",
+	"tinyint":                     "boolean", // Mysql's boolean
+	"float8":                      "double precision",
+	"double":                      "double precision",
+	"float4":                      "real",
+	"decimal":                     "numeric",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamptz",
+	"time without time zone":      "time",
+	"time with time zone":         "timetz",
+}
+
+// appliedVersion returns the highest migration version applied to t,
+// 0 if there is none.
+func (t *Table) appliedVersion(q Querier) (int, error) {
+	fnc := "Table.appliedVersion"
+
+	ok, err := tableExists(migrationsTab, q)
+	if err != nil {
+		return 0, fmt.Errorf(fnc+":%w", err)
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	s := "select coalesce(max(version),0) from " + quote(migrationsTab) +
+		" where tabname=" + FormatAtt(1) + ";"
+
+	Log("s:", s)
+	Log("sqlargs:", t.Name)
+
+	rows, err := dbQuery(q, s, t.Name)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return 0, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+	defer rows.Close()
+
+	var n int
+	if rows.Next() {
+		err = rows.Scan(&n)
+		if err != nil {
+			e := Err{Fix: "LIVEDB:error at scan(rows) for query:{{.Query}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Query", s},
+				},
+			}
+			return 0, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error at rows.Next for query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return 0, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return n, nil
+}
+
+// recordVersion records migration version of t,
+// creating table livedb_migrations if needed.
+func (t *Table) recordVersion(version int, creator string, q Querier) error {
+	fnc := "Table.recordVersion"
+
+	err := createMeta(migrationsTab, migrationsDefs, q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	s := "insert into " + quote(migrationsTab) + " (tabname,version,applied,appliedby)" +
+		" values (" + FormatAtt(1) + "," + FormatAtt(2) + "," + FormatNow() + "," + FormatAtt(3) + ");"
+	sqlargs := []interface{}{t.Name, version, creator}
+
+	Log("s:", s)
+	Log("sqlargs:", sqlargs)
+
+	_, err = dbExec(q, s, sqlargs...)
+	if err != nil {
+		e := Err{
+			Fix: "LIVEDB:error inserting by:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return nil
+}
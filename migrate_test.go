@@ -0,0 +1,157 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for schema migrations.
+
+package livedb
+
+import (
+	"fmt"
+	"testing"
+)
+
+const mitab = "tmigrate"
+
+type migrateTest struct {
+	version int
+	defs    []string
+	dryRun  bool
+	drop    bool // dropMissing
+	//
+	stmts int      // expected number of statements
+	cols  []string // expected specific columns afterwards
+	ok    bool
+}
+
+// TestMigrate tests adding, dropping and widening attributes
+// and refusing other changes.
+func TestMigrate(t *testing.T) {
+
+	creator := "TestMigrate"
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer func() {
+		e := Commit(tx) // end transaction
+		if e != nil {
+			e = translate(e, lang) // ******** l10n ********
+			t.Log(e)
+		}
+	}()
+
+	tab := Table{Name: mitab, Defs: []string{"a varchar(10)", "b integer"}}
+	err = tab.Create(tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	migrateTests := []migrateTest{
+		{1, []string{"a varchar(10)", "b integer", "c varchar(5)"}, true, false, 1, []string{"a", "b"}, true},       // dry run
+		{1, []string{"a varchar(10)", "b integer", "c varchar(5)"}, false, false, 1, []string{"a", "b", "c"}, true}, // add
+		{1, []string{"a varchar(10)"}, false, false, 0, []string{"a", "b", "c"}, true},                              // applied already
+		{2, []string{"a varchar(10)", "c varchar(5)"}, false, false, 0, nil, false},                                 // b not defined
+		{2, []string{"a varchar(10)", "c varchar(5)"}, true, true, 1, []string{"a", "b", "c"}, true},                // dry run drop
+		{2, []string{"a varchar(10)", "c varchar(5)"}, false, true, 1, []string{"a", "c"}, true},                    // drop
+		{3, []string{"a varchar(5)", "c varchar(5)"}, false, false, 0, nil, false},                                  // narrow
+		{3, []string{"a varchar(20)", "c varchar(5)"}, false, false, -1, []string{"a", "c"}, true},                  // widen
+		{4, []string{"a integer", "c varchar(5)"}, false, false, 0, nil, false},                                     // type changed
+		{4, []string{"a varchar(20) not null", "c varchar(5)"}, true, false, 0, nil, false},                         // nullability changed
+		{4, []string{"a character varying (20)", "c varchar(5)"}, false, false, -1, []string{"a", "c"}, true},       // same type
+		{5, []string{"a character varying (30)", "c varchar(5)"}, false, false, -1, []string{"a", "c"}, true},       // widen
+		{0, []string{"a varchar(20)", "c varchar(5)"}, false, false, 0, nil, false},                                 // version missing
+	}
+
+	for i, v := range migrateTests {
+		tab := Table{Name: mitab, Defs: v.defs}
+
+		stmts, err := tab.Migrate(v.version, creator, v.dryRun, v.drop, tx) // <------- ACTION
+		var cols []Column
+		if err == nil {
+			cols, err = tab.Columns(tx)
+		}
+		var specific []string
+		for j, col := range cols {
+			if j >= len(StdAtts) {
+				specific = append(specific, col.Name)
+			}
+		}
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		case v.stmts >= 0 && len(stmts) != v.stmts: // widening depends on database
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.stmts, "statements, got", stmts)
+		case fmt.Sprint(specific) != fmt.Sprint(v.cols):
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected columns", v.cols, "got", specific)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK", stmts)
+		}
+	}
+}
+
+// TestParseDef tests finding type clause and nullability of attribute definitions.
+func TestParseDef(t *testing.T) {
+
+	type parseDefTest struct {
+		def string
+		//
+		typ      string
+		nullable bool
+	}
+	parseDefTests := []parseDefTest{
+		{"a varchar(50)", "varchar(50)", true},
+		{"a varchar (50) not null", "varchar(50)", false},
+		{"a character varying(50) NOT NULL", "character varying(50)", false},
+		{"a decimal(10, 2) default 0", "decimal(10, 2)", true},
+		{"a double precision null", "double precision", true},
+		{"a integer primary key", "integer", false},
+		{"a", "", true},
+	}
+
+	for i, v := range parseDefTests {
+		typ, nullable := parseDef(v.def) // <------- ACTION
+		if typ != v.typ || nullable != v.nullable {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.typ, v.nullable, "got", typ, nullable)
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+}
+
+// TestCanonicalType tests that type names of databases are recognized as the same.
+func TestCanonicalType(t *testing.T) {
+
+	type canonicalTypeTest struct {
+		a, b string
+		//
+		same bool
+	}
+	canonicalTypeTests := []canonicalTypeTest{
+		{"varchar", "character varying", true},
+		{"VARCHAR", "character  varying", true},
+		{"int", "integer", true},
+		{"timestamp", "timestamp without time zone", true},
+		{"decimal(10,2)", "numeric", true},
+		{"varchar", "text", false},
+		{"integer", "bigint", false},
+	}
+
+	for i, v := range canonicalTypeTests {
+		same := canonicalType(v.a) == canonicalType(v.b) // <------- ACTION
+		if same != v.same {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.same, "got", same)
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+}
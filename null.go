@@ -0,0 +1,91 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// Layouts used to format date and timestamp attributes.
+const (
+	DateLayout = "2006-01-02"
+	TmspLayout = "2006-01-02 15:04:05.999999"
+)
+
+// layouts NullTime.Scan understands.
+var layouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	DateLayout,
+}
+
+// NullTime represents a date or timestamp attribute that may be null.
+//
+// Unlike sql.NullTime it scans text too, as not every database driver
+// returns time.Time for date and timestamp columns (e.g. Sqlite, Mysql).
+//
+// Livedb stores times in UTC: Value converts Time to UTC,
+// Scan returns Time in UTC.
+type NullTime struct {
+	Time  time.Time
+	Valid bool // Valid is true if Time is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (nt *NullTime) Scan(value interface{}) error {
+	fnc := "NullTime.Scan"
+
+	var s string
+	switch v := value.(type) {
+	case nil:
+		nt.Time, nt.Valid = time.Time{}, false
+		return nil
+	case time.Time:
+		nt.Time, nt.Valid = v.UTC(), true
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		err := Err{
+			Fix: "LIVEDB:cannot scan {{.Type}} into time",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Type", fmt.Sprintf("%T", value)},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, s) // UTC without zone
+		if err == nil {
+			nt.Time, nt.Valid = t.UTC(), true
+			return nil
+		}
+	}
+
+	err := Err{
+		Fix: "LIVEDB:not a valid timestamp",
+	}
+	return fmt.Errorf(fnc+":%w:"+s, err)
+}
+
+// Value implements the driver.Valuer interface.
+func (nt NullTime) Value() (driver.Value, error) {
+	if !nt.Valid {
+		return nil, nil
+	}
+	return nt.Time.UTC().Format(TmspLayout), nil
+}
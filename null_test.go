@@ -0,0 +1,285 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for NullTime and nullable attributes.
+
+package livedb
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+type nullTimeTest struct {
+	in interface{}
+	//
+	want  string // formatted with TmspLayout, "" for NULL
+	valid bool
+	ok    bool
+}
+
+// TestNullTime tests scanning of dates and timestamps as returned by drivers.
+func TestNullTime(t *testing.T) {
+
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 890000000, time.UTC)
+
+	nullTimeTests := []nullTimeTest{
+		{nil, "", false, true},
+		{ts, "2021-03-04 05:06:07.89", true, true},
+		{"2021-03-04", "2021-03-04 00:00:00", true, true},
+		{"2021-03-04 05:06:07.890", "2021-03-04 05:06:07.89", true, true},
+		{[]byte("2021-03-04 05:06:07"), "2021-03-04 05:06:07", true, true},
+		{"2021-03-04T05:06:07.89Z", "2021-03-04 05:06:07.89", true, true},
+		{"no time", "", false, false},
+		{42, "", false, false},
+	}
+
+	for i, v := range nullTimeTests {
+		var nt NullTime
+		err := nt.Scan(v.in) // <------- ACTION
+		got := ""
+		if nt.Valid {
+			got = nt.Time.Format(TmspLayout)
+		}
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		case nt.Valid != v.valid || got != v.want:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.want, v.valid, "got", got, nt.Valid)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+
+	v, err := NullTime{Time: ts, Valid: true}.Value()
+	if err != nil || v != "2021-03-04 05:06:07.89" {
+		t.Error("expected value 2021-03-04 05:06:07.89, got", v, err)
+	}
+	v, err = NullTime{}.Value()
+	if err != nil || v != nil {
+		t.Error("expected nil value, got", v, err)
+	}
+}
+
+// TestNullTimeUTC tests that a time of another zone is stored in UTC
+// and read back as the same instant.
+func TestNullTimeUTC(t *testing.T) {
+
+	cest := time.FixedZone("CEST", 2*60*60)
+	in := time.Date(2021, 3, 4, 1, 6, 7, 890000000, cest)
+
+	v, err := NullTime{Time: in, Valid: true}.Value() // <------- ACTION
+	if err != nil || v != "2021-03-03 23:06:07.89" {
+		t.Error("expected value 2021-03-03 23:06:07.89, got", v, err)
+	}
+
+	var out NullTime
+	err = GDb.QueryRow("select "+FormatTmsp(1)+";", NullTime{Time: in, Valid: true}).Scan(&out) // <------- ACTION
+	switch {
+	case err != nil:
+		err = translate(err, lang) // ******** l10n ********
+		t.Error("expected ok, got error:", err)
+	case !out.Time.Equal(in):
+		t.Error("expected", in, "got", out.Time)
+	case out.Time.Location() != time.UTC:
+		t.Error("expected UTC, got", out.Time.Location())
+	default:
+		t.Log("OK", in, "->", out.Time)
+	}
+}
+
+// TestEqualIdv tests comparing individual attributes
+// with pointer and slice fields.
+func TestEqualIdv(t *testing.T) {
+
+	type idv struct {
+		num  *int
+		data []byte
+	}
+	one, one2, two := 1, 1, 2
+
+	type equalTest struct {
+		a, b interface{}
+		//
+		equal bool
+	}
+	equalTests := []equalTest{
+		{idv{&one, []byte("x")}, idv{&one2, []byte("x")}, true},
+		{idv{nil, nil}, idv{nil, nil}, true},
+		{idv{&one, nil}, idv{&two, nil}, false},
+		{idv{&one, nil}, idv{nil, nil}, false},
+		{idv{nil, []byte("x")}, idv{nil, []byte("y")}, false},
+		{Te{str1: "a"}, Te{str1: "a"}, true},
+		{Te{str1: "a"}, Te{str2: "a"}, false},
+	}
+
+	tab := Table{}
+	for i, v := range equalTests {
+		equal := tab.equalIdv(v.a, v.b) // <------- ACTION
+		if equal != v.equal {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.equal, "got", equal)
+		}
+	}
+
+	tab.Vals = teVals
+	if !tab.equalIdv(Te{num: 0}, Te{}) {
+		t.Error("expected equal values")
+	}
+}
+
+const nutab = "tnull"
+
+var nuAtts = []string{
+	"s",
+	"n",
+}
+
+var nuDefs = []string{
+	"s varchar(10)",
+	"n integer",
+}
+
+// Nu has nullable attributes only: nil is NULL.
+type Nu struct {
+	s *string
+	n *int
+}
+
+func nuScan(rows *sql.Rows) (Record, error) {
+	fnc := "nuScan"
+
+	std := Std{}
+	nullUntil := sql.NullString{}
+	nullEnded := sql.NullString{}
+	nullEndedBy := sql.NullString{}
+
+	nu := Nu{}
+	nullS := sql.NullString{}
+	nullN := sql.NullInt64{}
+
+	err := rows.Scan(
+		&(std.ID),
+		&(std.Begin),
+		&(nullUntil),
+		&(std.Pkey),
+		&(std.Created), &(std.CreatedBy),
+		&(nullEnded), &(nullEndedBy),
+		//
+		&(nullS),
+		&(nullN),
+	)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error scanning row}"}
+		return Record{}, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	std.Until = nullUntil.String
+	std.Ended = nullEnded.String
+	std.EndedBy = nullEndedBy.String
+
+	if nullS.Valid {
+		nu.s = &nullS.String
+	}
+	if nullN.Valid {
+		n := int(nullN.Int64)
+		nu.n = &n
+	}
+
+	return Record{Std: std, Idv: nu}, nil
+}
+
+// nuVals stores values as they are, zero values too.
+func nuVals(in interface{}) []interface{} {
+	nu := in.(Nu)
+
+	vals := make([]interface{}, len(nuAtts)) // nil indicates NULL
+	if nu.s != nil {
+		vals[0] = *nu.s
+	}
+	if nu.n != nil {
+		vals[1] = *nu.n
+	}
+
+	return vals
+}
+
+type nullStep struct {
+	ts   string
+	null bool // store NULL, otherwise "" and 0
+}
+
+// TestNullRoundTrip tests that "" and 0 are stored as they are
+// and nil values as NULL, by insert as well as by update.
+func TestNullRoundTrip(t *testing.T) {
+
+	creator := "TestNullRoundTrip"
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer Rollback(tx) // leave no test tables
+
+	tab := Table{Name: nutab, Defs: nuDefs, Atts: nuAtts, Vals: nuVals, Scan: nuScan}
+	err = tab.Create(tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	id, err := tab.NewID(creator, tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	for i, v := range nullSteps {
+		s, n := "", 0
+		nu := Nu{&s, &n}
+		if v.null {
+			nu = Nu{}
+		}
+
+		if i == 0 {
+			tab.New = Record{Idv: nu}
+			_, err = tab.Start(id, v.ts, creator, tx) // <------- ACTION
+		} else {
+			recs, e := tab.ByIDTs(id, v.ts, tx)
+			if e != nil || len(recs) != 1 {
+				t.Fatal("#"+fmt.Sprintf("%d", i+1), "expected 1 record, got", len(recs), e)
+			}
+			tab.Old = recs[0]
+			tab.New = Record{Std: recs[0].Std, Idv: nu}
+			_, err = tab.Change(v.ts, creator, tx) // <------- ACTION
+		}
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		}
+
+		recs, err := tab.ByIDTs(id, v.ts, tx)
+		if err != nil || len(recs) != 1 {
+			t.Fatal("#"+fmt.Sprintf("%d", i+1), "expected 1 record, got", len(recs), err)
+		}
+		got := recs[0].Idv.(Nu)
+		switch {
+		case v.null && (got.s != nil || got.n != nil):
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected NULL, got", got.s, got.n)
+		case !v.null && (got.s == nil || *got.s != "" || got.n == nil || *got.n != 0):
+			t.Error("#"+fmt.Sprintf("%d", i+1), `expected "" and 0, got`, got.s, got.n)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// Options for read functions returning several Records
+// (ByTs, ByTsAndXs, ByIDTs, ByPeriod, History and their Iterate variants
+// IterateByTs, IterateByTsAndXs, IterateByPeriod, IterateHistory):
+//
+//	recs, err := t.ByTs(ts, q, livedb.WithAfter(id, begin), livedb.WithLimit(50))
+//
+// Options are checked by the read functions; invalid options make them fail.
+// Options apply to one call only; other functions ignore them.
+
+// readOptions holds the options of one read call.
+type readOptions struct {
+	limit   int      // see WithLimit
+	after   *keyset  // see WithAfter
+	orderBy []string // see WithOrderBy; quoted by optionsPrecs
+	filter  *Filter  // see WithFilter
+}
+
+// withOptions returns a copy of t holding the options opts
+// for one read call. t itself remains unchanged, so options
+// do not carry over to later calls.
+func (t *Table) withOptions(opts []func(*Table)) *Table {
+	c := *t
+	c.opts = &readOptions{}
+	for _, opt := range opts { // non-default options
+		opt(&c)
+	}
+	return &c
+}
+
+// keyset holds the position given by WithAfter.
+type keyset struct {
+	id    int
+	begin string
+}
+
+// WithLimit limits the number of Records returned to n.
+// n = 0 means no limit.
+func WithLimit(n int) func(*Table) {
+	return func(t *Table) {
+		if t.opts != nil { // read call
+			t.opts.limit = n
+		}
+	}
+}
+
+// WithAfter returns only Records following the Record with ID id
+// and Begin begin, in order of ID and Begin (keyset pagination).
+// Pass the ID and Begin of the last Record of the previous page.
+//
+// WithAfter cannot be combined with WithOrderBy.
+func WithAfter(id int, begin string) func(*Table) {
+	return func(t *Table) {
+		if t.opts != nil { // read call
+			t.opts.after = &keyset{id: id, begin: begin}
+		}
+	}
+}
+
+// WithOrderBy orders Records by the given columns
+// instead of the default order. A column may be followed
+// by "asc" or "desc", e.g. WithOrderBy("name desc", "begin").
+// Columns must be in StdAtts or t.Atts.
+// ID and Begin are always appended to make the order unique.
+func WithOrderBy(cols ...string) func(*Table) {
+	return func(t *Table) {
+		if t.opts != nil { // read call
+			t.opts.orderBy = cols
+		}
+	}
+}
+
+// optionsPrecs checks the options and normalizes the order columns.
+func (t *Table) optionsPrecs() error {
+	fnc := "Table.optionsPrecs"
+
+	o := t.opts
+
+	if o.limit < 0 {
+		err := Err{
+			Fix: "LIVEDB:invalid limit {{.Int}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Int", o.limit},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
+	}
+
+	if o.after != nil {
+		if o.after.id == 0 {
+			err := Err{
+				Fix: "LIVEDB:{{.Name}} missing",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Name", "WithAfter id"},
+				},
+			}
+			return fmt.Errorf(fnc+":%w", err)
+		}
+		if o.after.begin == "" {
+			err := Err{
+				Fix: "LIVEDB:{{.Name}} missing",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Name", "WithAfter begin"},
+				},
+			}
+			return fmt.Errorf(fnc+":%w", err)
+		}
+		if len(o.orderBy) > 0 {
+			err := Err{Fix: "LIVEDB:WithAfter cannot be combined with WithOrderBy"}
+			return fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
+		}
+	}
+
+	if o.filter != nil {
+		err := t.filterPrecs(*o.filter)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	orderBy := make([]string, 0, len(o.orderBy))
+	for _, col := range o.orderBy {
+		c, err := t.orderColumn(col)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+		orderBy = append(orderBy, c)
+	}
+	o.orderBy = orderBy
+
+	return nil
+}
+
+// orderColumn returns col quoted
+// if it names a column of t, optionally followed by asc or desc.
+func (t *Table) orderColumn(col string) (string, error) {
+	fnc := "Table.orderColumn"
+
+	fields := strings.Fields(col)
+
+	dir := ""
+	if len(fields) == 2 {
+		switch strings.ToLower(fields[1]) {
+		case "asc", "desc":
+			dir = " " + strings.ToLower(fields[1])
+		default:
+			fields = nil // invalid
+		}
+	}
+
+	if len(fields) == 1 || len(fields) == 2 {
+		c := t.column(fields[0])
+		if c != "" {
+			return c + dir, nil
+		}
+	}
+
+	err := Err{
+		Fix: "LIVEDB:unknown order column {{.Name}}",
+		Var: []struct {
+			Name  string
+			Value interface{}
+		}{
+			{"Name", col},
+		},
+	}
+	return "", fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
+}
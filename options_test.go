@@ -0,0 +1,154 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for options of read functions.
+
+package livedb
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestOptions tests WithLimit, WithAfter and WithOrderBy.
+func TestOptions(t *testing.T) {
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	recs, err := tab.ByTs(Now, nil)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if len(recs) < 2 {
+		t.Skip("not enough records in", tetab) // filled by TestStart etc.
+	}
+	first, n := recs[0].Std, len(recs)
+
+	type optionsTest struct {
+		opts []func(*Table)
+		//
+		want int // expected number of Records
+		ok   bool
+	}
+	optionsTests := []optionsTest{
+		{[]func(*Table){WithLimit(0)}, n, true},
+		{[]func(*Table){WithLimit(1)}, 1, true},
+		{[]func(*Table){WithLimit(-1)}, 0, false},
+		{[]func(*Table){WithOrderBy("str1 desc", "NUM")}, n, true},
+		{[]func(*Table){WithOrderBy("until", "createdby asc")}, n, true},
+		{[]func(*Table){WithOrderBy("nosuch")}, 0, false},
+		{[]func(*Table){WithOrderBy("str1 sideways")}, 0, false},
+		{[]func(*Table){WithOrderBy("str1;delete from ttest")}, 0, false},
+		{[]func(*Table){WithAfter(first.ID, first.Begin)}, n - 1, true},
+		{[]func(*Table){WithAfter(first.ID, first.Begin), WithLimit(1)}, 1, true},
+		{[]func(*Table){WithAfter(0, first.Begin)}, 0, false},
+		{[]func(*Table){WithAfter(first.ID, "")}, 0, false},
+		{[]func(*Table){WithAfter(first.ID, first.Begin), WithOrderBy("str1")}, 0, false},
+	}
+
+	for i, v := range optionsTests {
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+		recs, err := tab.ByTs(Now, nil, v.opts...) // <------- ACTION
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		case len(recs) != v.want:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.want, "records, got", len(recs))
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestPaging tests reading all Records page by page.
+func TestPaging(t *testing.T) {
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	recs, err := tab.ByTs(Now, nil)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	opts := []func(*Table){WithLimit(2)}
+	n := 0
+	for {
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+		page, err := tab.ByTs(Now, nil, opts...) // <------- ACTION
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, rec := range page {
+			if n >= len(recs) || rec.Std.Pkey != recs[n].Std.Pkey {
+				t.Fatal("page record", n+1, "differs from ByTs")
+			}
+			n++
+		}
+		last := page[len(page)-1].Std
+		opts = []func(*Table){WithLimit(2), WithAfter(last.ID, last.Begin)}
+	}
+
+	if n != len(recs) {
+		t.Error("expected", len(recs), "records, got", n)
+	}
+}
+
+// TestOptionsReuse tests that options apply to one read call only
+// when the same Table is read several times.
+func TestOptionsReuse(t *testing.T) {
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	recs, err := tab.ByTs(Now, nil)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if len(recs) < 2 {
+		t.Skip("not enough records in", tetab) // filled by TestStart etc.
+	}
+	first, n := recs[0].Std, len(recs)
+
+	type reuseTest struct {
+		opts []func(*Table)
+		//
+		want int // expected number of Records
+	}
+	reuseTests := []reuseTest{
+		{[]func(*Table){WithOrderBy("str1 desc"), WithLimit(1)}, 1},
+		{[]func(*Table){WithOrderBy("str1 desc"), WithLimit(1)}, 1}, // same again
+		{[]func(*Table){WithAfter(first.ID, first.Begin), WithLimit(1)}, 1},
+		{[]func(*Table){WithAfter(first.ID, first.Begin), WithLimit(1)}, 1}, // same again
+		{[]func(*Table){WithAfter(first.ID, first.Begin)}, n - 1},           // no limit left
+		{nil, n}, // no options left
+	}
+
+	for i, v := range reuseTests {
+		recs, err := tab.ByTs(Now, nil, v.opts...) // <------- ACTION (same Table)
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+			continue
+		}
+		if len(recs) != v.want {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.want, "records, got", len(recs))
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+}
@@ -8,12 +8,11 @@ import (
 	"bytes"
 	"database/sql"
 	"fmt"
-	"strings"
 
 	. "github.com/hwheinzen/stringl10n/mistake"
 )
 
-func (t *Table) readID(id int, tx *sql.Tx) (stdID, error) {
+func (t *Table) readID(id int, q Querier) (stdID, error) {
 	fnc := "Table.readID"
 
 	var buf bytes.Buffer
@@ -25,12 +24,12 @@ func (t *Table) readID(id int, tx *sql.Tx) (stdID, error) {
 	put("select ")
 	for i, att := range stdIDAtts {
 		if i == 0 { // first one
-			put(att)
+			put(quote(att))
 			continue
 		}
-		put("," + att)
+		put("," + quote(att))
 	}
-	put(" from " + t.Name + "id")
+	put(" from " + quote(t.Name+"id"))
 
 	num++
 	put(" where id=" + FormatAtt(num) + ";")
@@ -41,7 +40,7 @@ func (t *Table) readID(id int, tx *sql.Tx) (stdID, error) {
 	Log("s:", s)
 	Log("sqlargs:", sqlargs)
 
-	stdID, err := t.queryID(s, sqlargs, tx)
+	stdID, err := t.queryID(s, sqlargs, q)
 	if err != nil {
 		return stdID, fmt.Errorf(fnc+":%w:", err)
 	}
@@ -49,7 +48,7 @@ func (t *Table) readID(id int, tx *sql.Tx) (stdID, error) {
 	return stdID, nil
 }
 
-func (t *Table) queryID(s string, sqlargs []interface{}, tx *sql.Tx) (stdID, error) {
+func (t *Table) queryID(s string, sqlargs []interface{}, q Querier) (stdID, error) {
 	fnc := "Table.queryID"
 
 	var stdID stdID
@@ -57,11 +56,7 @@ func (t *Table) queryID(s string, sqlargs []interface{}, tx *sql.Tx) (stdID, err
 	rows := &sql.Rows{}
 	var err error
 
-	if tx != nil {
-		rows, err = tx.Query(s, sqlargs...)
-	} else {
-		rows, err = GDb.Query(s, sqlargs...)
-	}
+	rows, err = dbQuery(q, s, sqlargs...)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
 			Var: []struct {
@@ -130,7 +125,7 @@ func scanID(rows *sql.Rows) (stdID, error) {
 	return stdID, nil
 }
 
-func (t *Table) countByID(id int, tx *sql.Tx) (int, error) {
+func (t *Table) countByID(id int, q Querier) (int, error) {
 	fnc := "Table.countByID"
 
 	var buf bytes.Buffer
@@ -140,7 +135,7 @@ func (t *Table) countByID(id int, tx *sql.Tx) (int, error) {
 	num := int(0)
 
 	put("select count(*)")
-	put(" from " + t.Name)
+	put(" from " + quote(t.Name))
 
 	num++
 	put(" where id=" + FormatAtt(num))
@@ -153,11 +148,7 @@ func (t *Table) countByID(id int, tx *sql.Tx) (int, error) {
 
 	rows := &sql.Rows{}
 	var err error
-	if tx != nil {
-		rows, err = tx.Query(s, sqlargs...)
-	} else {
-		rows, err = GDb.Query(s, sqlargs...)
-	}
+	rows, err = dbQuery(q, s, sqlargs...)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
 			Var: []struct {
@@ -205,19 +196,17 @@ func (t *Table) countByID(id int, tx *sql.Tx) (int, error) {
 }
 
 // Table.ByKey returns a Record and possibly an error.
-func (t *Table) ByKey(key int, tx *sql.Tx, opts ...func(*Table)) ([]Record, error) {
+func (t *Table) ByKey(key int, q Querier, opts ...func(*Table)) ([]Record, error) {
 	fnc := "Table.ByKey"
 
-	for _, opt := range opts { // non-default options
-		opt(t)
-	}
+	t = t.withOptions(opts) // per call
 
-	err := t.byKeyPrecs(key) // preconditions
+	err := t.byKeyPrecs(key, q) // preconditions
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
 
-	recs, err := t.byKey(key, tx)
+	recs, err := t.byKey(key, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -225,10 +214,10 @@ func (t *Table) ByKey(key int, tx *sql.Tx, opts ...func(*Table)) ([]Record, erro
 	return recs, nil
 }
 
-func (t *Table) byKeyPrecs(key int) error {
+func (t *Table) byKeyPrecs(key int, q Querier) error {
 	fnc := "Table.byKeyPrecs"
 
-	if GDb == nil {
+	if isNil(q) && GDb == nil {
 		err := Err{Fix: "LIVEDB:access needs at least database object"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -250,6 +239,10 @@ func (t *Table) byKeyPrecs(key int) error {
 		err := Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 	if t.Atts == nil {
 		err := Err{
 			Fix: "LIVEDB:{{.Name}} missing",
@@ -278,7 +271,7 @@ func (t *Table) byKeyPrecs(key int) error {
 	return nil
 }
 
-func (t *Table) byKey(key int, tx *sql.Tx) ([]Record, error) {
+func (t *Table) byKey(key int, q Querier) ([]Record, error) {
 	fnc := "Table.byKey"
 
 	var buf bytes.Buffer
@@ -290,15 +283,15 @@ func (t *Table) byKey(key int, tx *sql.Tx) ([]Record, error) {
 	put("select ")
 	for i, att := range StdAtts {
 		if i == 0 { // first one
-			put(att)
+			put(quote(att))
 			continue
 		}
-		put("," + att)
+		put("," + quote(att))
 	}
 	for _, att := range t.Atts {
-		put("," + att)
+		put("," + quote(att))
 	}
-	put(" from " + t.Name)
+	put(" from " + quote(t.Name))
 
 	num++
 	put(" where pkey=" + FormatAtt(num) + ";")
@@ -309,7 +302,7 @@ func (t *Table) byKey(key int, tx *sql.Tx) ([]Record, error) {
 	Log("s:", s)
 	Log("sqlargs:", sqlargs)
 
-	recs, err := t.Query(s, sqlargs, t.Scan, tx)
+	recs, err := t.Query(s, sqlargs, t.Scan, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -318,19 +311,17 @@ func (t *Table) byKey(key int, tx *sql.Tx) ([]Record, error) {
 }
 
 // Table.ByIDBegin returns a Record and possibly an error.
-func (t *Table) ByIDBegin(id int, begin string, tx *sql.Tx, opts ...func(*Table)) ([]Record, error) {
+func (t *Table) ByIDBegin(id int, begin string, q Querier, opts ...func(*Table)) ([]Record, error) {
 	fnc := "Table.ByIDBegin"
 
-	for _, opt := range opts { // non-default options
-		opt(t)
-	}
+	t = t.withOptions(opts) // per call
 
-	err := t.byIDBeginPrecs(id, begin) // preconditions
+	err := t.byIDBeginPrecs(id, begin, q) // preconditions
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
 
-	recs, err := t.byIDBegin(id, begin, tx)
+	recs, err := t.byIDBegin(id, begin, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -338,10 +329,10 @@ func (t *Table) ByIDBegin(id int, begin string, tx *sql.Tx, opts ...func(*Table)
 	return recs, nil
 }
 
-func (t *Table) byIDBeginPrecs(id int, ts string) error {
+func (t *Table) byIDBeginPrecs(id int, ts string, q Querier) error {
 	fnc := "Table.byIDBeginPrecs"
 
-	if GDb == nil {
+	if isNil(q) && GDb == nil {
 		err := Err{Fix: "LIVEDB:access needs at least database object"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -376,6 +367,10 @@ func (t *Table) byIDBeginPrecs(id int, ts string) error {
 		err := Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 
 	if t.Scan == nil {
 		err := Err{
@@ -393,7 +388,7 @@ func (t *Table) byIDBeginPrecs(id int, ts string) error {
 	return nil
 }
 
-func (t *Table) byIDBegin(id int, begin string, tx *sql.Tx) ([]Record, error) {
+func (t *Table) byIDBegin(id int, begin string, q Querier) ([]Record, error) {
 	fnc := "Table.byIDBegin"
 
 	var buf bytes.Buffer
@@ -405,15 +400,15 @@ func (t *Table) byIDBegin(id int, begin string, tx *sql.Tx) ([]Record, error) {
 	put("select ")
 	for i, att := range StdAtts {
 		if i == 0 { // first one
-			put(att)
+			put(quote(att))
 			continue
 		}
-		put("," + att)
+		put("," + quote(att))
 	}
 	for _, att := range t.Atts {
-		put("," + att)
+		put("," + quote(att))
 	}
-	put(" from " + t.Name)
+	put(" from " + quote(t.Name))
 
 	num++
 	put(" where id=" + FormatAtt(num))
@@ -431,7 +426,7 @@ func (t *Table) byIDBegin(id int, begin string, tx *sql.Tx) ([]Record, error) {
 	Log("s:", s)
 	Log("sqlargs:", sqlargs)
 
-	recs, err := t.Query(s, sqlargs, t.Scan, tx)
+	recs, err := t.Query(s, sqlargs, t.Scan, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -440,19 +435,17 @@ func (t *Table) byIDBegin(id int, begin string, tx *sql.Tx) ([]Record, error) {
 }
 
 // Table.ByIDUntil returns a Record and possibly an error.
-func (t *Table) ByIDUntil(id int, until string, tx *sql.Tx, opts ...func(*Table)) ([]Record, error) {
+func (t *Table) ByIDUntil(id int, until string, q Querier, opts ...func(*Table)) ([]Record, error) {
 	fnc := "Table.ByIDUntil"
 
-	for _, opt := range opts { // non-default options
-		opt(t)
-	}
+	t = t.withOptions(opts) // per call
 
-	err := t.byIDUntilPrecs(id, until) // preconditions
+	err := t.byIDUntilPrecs(id, until, q) // preconditions
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
 
-	recs, err := t.byIDUntil(id, until, tx)
+	recs, err := t.byIDUntil(id, until, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -460,10 +453,10 @@ func (t *Table) ByIDUntil(id int, until string, tx *sql.Tx, opts ...func(*Table)
 	return recs, nil
 }
 
-func (t *Table) byIDUntilPrecs(id int, ts string) error {
+func (t *Table) byIDUntilPrecs(id int, ts string, q Querier) error {
 	fnc := "Table.byIDUntilPrecs"
 
-	if GDb == nil {
+	if isNil(q) && GDb == nil {
 		err := Err{Fix: "LIVEDB:access needs at least database object"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -498,6 +491,10 @@ func (t *Table) byIDUntilPrecs(id int, ts string) error {
 		err := Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 
 	if t.Scan == nil {
 		err := Err{
@@ -515,7 +512,7 @@ func (t *Table) byIDUntilPrecs(id int, ts string) error {
 	return nil
 }
 
-func (t *Table) byIDUntil(id int, until string, tx *sql.Tx) ([]Record, error) {
+func (t *Table) byIDUntil(id int, until string, q Querier) ([]Record, error) {
 	fnc := "Table.byIDUntil"
 
 	var buf bytes.Buffer
@@ -527,15 +524,15 @@ func (t *Table) byIDUntil(id int, until string, tx *sql.Tx) ([]Record, error) {
 	put("select ")
 	for i, att := range StdAtts {
 		if i == 0 { // first one
-			put(att)
+			put(quote(att))
 			continue
 		}
-		put("," + att)
+		put("," + quote(att))
 	}
 	for _, att := range t.Atts {
-		put("," + att)
+		put("," + quote(att))
 	}
-	put(" from " + t.Name)
+	put(" from " + quote(t.Name))
 
 	num++
 	put(" where id=" + FormatAtt(num))
@@ -553,7 +550,7 @@ func (t *Table) byIDUntil(id int, until string, tx *sql.Tx) ([]Record, error) {
 	Log("s:", s)
 	Log("sqlargs:", sqlargs)
 
-	recs, err := t.Query(s, sqlargs, t.Scan, tx)
+	recs, err := t.Query(s, sqlargs, t.Scan, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -569,19 +566,18 @@ type NameValue struct {
 
 // Table.ByTsAndXs returns Records and possibly an error.
 // Results are ordered by all names of xs and ID and Begin.
-func (t *Table) ByTsAndXs(ts string, xs []NameValue, tx *sql.Tx, opts ...func(*Table)) ([]Record, error) {
+// Options WithLimit, WithAfter, WithOrderBy and WithFilter are supported.
+func (t *Table) ByTsAndXs(ts string, xs []NameValue, q Querier, opts ...func(*Table)) ([]Record, error) {
 	fnc := "Table.ByTsAndXs"
 
-	for _, opt := range opts { // non-default options
-		opt(t)
-	}
+	t = t.withOptions(opts) // per call
 
-	err := t.byTsAndXsPrecs(ts, xs) // preconditions
+	err := t.byTsAndXsPrecs(ts, xs, q) // preconditions
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
 
-	recs, err := t.byTsAndXs(ts, xs, tx)
+	recs, err := t.byTsAndXs(ts, xs, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -589,10 +585,31 @@ func (t *Table) ByTsAndXs(ts string, xs []NameValue, tx *sql.Tx, opts ...func(*T
 	return recs, nil
 }
 
-func (t *Table) byTsAndXsPrecs(ts string, xs []NameValue) error {
+// Table.IterateByTsAndXs calls fn for each Record selected like ByTsAndXs
+// without loading all of them into memory.
+// If fn returns an error the iteration stops and the error is returned.
+func (t *Table) IterateByTsAndXs(ts string, xs []NameValue, q Querier, fn func(Record) error, opts ...func(*Table)) error {
+	fnc := "Table.IterateByTsAndXs"
+
+	t = t.withOptions(opts) // per call
+
+	err := t.byTsAndXsPrecs(ts, xs, q) // preconditions
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	err = t.iterateByTsAndXs(ts, xs, q, fn)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+func (t *Table) byTsAndXsPrecs(ts string, xs []NameValue, q Querier) error {
 	fnc := "Table.byTsAndXsPrecs"
 
-	if GDb == nil {
+	if isNil(q) && GDb == nil {
 		err := Err{Fix: "LIVEDB:access needs at least database object"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -622,11 +639,21 @@ func (t *Table) byTsAndXsPrecs(ts string, xs []NameValue) error {
 		}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	for _, x := range xs {
+		err := t.columnPrecs(x.Name)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
 
 	if t.Name == "" {
 		err := Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 
 	if t.Scan == nil {
 		err := Err{
@@ -641,30 +668,48 @@ func (t *Table) byTsAndXsPrecs(ts string, xs []NameValue) error {
 		return fmt.Errorf(fnc+":%w", err)
 	}
 
+	err = t.optionsPrecs() // WithLimit etc.
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
 	return nil
 }
 
-func (t *Table) byTsAndXs(ts string, xs []NameValue, tx *sql.Tx) ([]Record, error) {
+func (t *Table) byTsAndXs(ts string, xs []NameValue, q Querier) ([]Record, error) {
 	fnc := "Table.byTsAndXs"
 
+	s, sqlargs := t.byTsAndXsQuery(ts, xs)
+
+	recs, err := t.Query(s, sqlargs, t.Scan, q)
+	if err != nil {
+		return []Record{}, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return recs, nil
+}
+
+func (t *Table) iterateByTsAndXs(ts string, xs []NameValue, q Querier, fn func(Record) error) error {
+	fnc := "Table.iterateByTsAndXs"
+
+	s, sqlargs := t.byTsAndXsQuery(ts, xs)
+
+	err := t.Iterate(s, sqlargs, t.Scan, q, fn)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+func (t *Table) byTsAndXsQuery(ts string, xs []NameValue) (string, []interface{}) {
 	var buf bytes.Buffer
 	var put = buf.WriteString // write method
 
 	sqlargs := []interface{}{}
 	num := int(0)
 
-	put("select ")
-	for i, att := range StdAtts {
-		if i == 0 { // first one
-			put(att)
-			continue
-		}
-		put("," + att)
-	}
-	for _, att := range t.Atts {
-		put("," + att)
-	}
-	put(" from " + t.Name)
+	t.putSelect(&buf)
 
 	if t.New.Std.Begin == Now {
 		put(" where begin<=" + FormatNow())
@@ -678,44 +723,108 @@ func (t *Table) byTsAndXs(ts string, xs []NameValue, tx *sql.Tx) ([]Record, erro
 	sqlargs = append(sqlargs, ts)
 	for _, x := range xs {
 		num++
-		put(" and " + x.Name + "=" + FormatAtt(num))
+		put(" and " + t.column(x.Name) + "=" + FormatAtt(num))
 		sqlargs = append(sqlargs, x.Value)
 	}
 
-	put(" order by ")
+	var order []string
 	for _, x := range xs {
-		put(x.Name + ",")
+		order = append(order, t.column(x.Name))
 	}
-	put("id,begin;")
+	sqlargs = t.putOptions(&buf, order, num, sqlargs)
 
 	s := buf.String()
 
 	Log("s:", s)
 	Log("sqlargs:", sqlargs)
 
-	recs, err := t.Query(s, sqlargs, t.Scan, tx)
-	if err != nil {
-		return []Record{}, fmt.Errorf(fnc+":%w", err)
+	return s, sqlargs
+}
+
+// putSelect writes the select clause for all columns of t.
+func (t *Table) putSelect(buf *bytes.Buffer) {
+	var put = buf.WriteString // write method
+
+	put("select ")
+	for i, att := range StdAtts {
+		if i == 0 { // first one
+			put(quote(att))
+			continue
+		}
+		put("," + quote(att))
+	}
+	for _, att := range t.Atts {
+		put("," + quote(att))
 	}
+	put(" from " + quote(t.Name))
+}
 
-	return recs, nil
+// putOptions writes the conditions of WithFilter and WithAfter,
+// the order by clause and the limit of WithLimit.
+// Without WithOrderBy or WithAfter, the results are ordered by
+// the columns of order, ID and Begin.
+// num is the number of the last argument.
+func (t *Table) putOptions(buf *bytes.Buffer, order []string, num int, sqlargs []interface{}) []interface{} {
+	var put = buf.WriteString // write method
+
+	o := readOptions{} // internal calls have no options
+	if t.opts != nil {
+		o = *t.opts
+	}
+
+	if o.filter != nil { // WithFilter
+		put(" and ")
+		num, sqlargs = t.putFilter(buf, *o.filter, num, sqlargs)
+	}
+	if o.after != nil { // WithAfter
+		num++
+		put(" and (id>" + FormatAtt(num))
+		sqlargs = append(sqlargs, fmt.Sprint(o.after.id))
+		num++
+		put(" or id=" + FormatAtt(num))
+		sqlargs = append(sqlargs, fmt.Sprint(o.after.id))
+		num++
+		put(" and begin>" + FormatTmsp(num) + ")")
+		sqlargs = append(sqlargs, o.after.begin)
+	}
+
+	put(" order by ")
+	switch {
+	case len(o.orderBy) > 0: // WithOrderBy
+		for _, col := range o.orderBy {
+			put(col + ",")
+		}
+	case o.after != nil: // keyset order
+	default:
+		for _, col := range order {
+			put(col + ",")
+		}
+	}
+	put("id,begin")
+
+	if o.limit > 0 { // WithLimit
+		put(" limit " + fmt.Sprint(o.limit))
+	}
+	put(";")
+
+	return sqlargs
 }
 
 // Table.ByTs returns Records and possibly an error.
-func (t *Table) ByTs(ts string, tx *sql.Tx, opts ...func(*Table)) ([]Record, error) {
+// Results are ordered by ID and Begin.
+// Options WithLimit, WithAfter, WithOrderBy and WithFilter are supported.
+func (t *Table) ByTs(ts string, q Querier, opts ...func(*Table)) ([]Record, error) {
 	fnc := "Table.ByTs"
 
-	for _, opt := range opts { // non-default options
-		opt(t)
-	}
+	t = t.withOptions(opts) // per call
 
-	err := t.byTsPrecs(ts) // preconditions
+	err := t.byTsPrecs(ts, q) // preconditions
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
 
 	var xs []NameValue // empty
-	recs, err := t.byTsAndXs(ts, xs, tx)
+	recs, err := t.byTsAndXs(ts, xs, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -723,10 +832,32 @@ func (t *Table) ByTs(ts string, tx *sql.Tx, opts ...func(*Table)) ([]Record, err
 	return recs, nil
 }
 
-func (t *Table) byTsPrecs(ts string) error {
+// Table.IterateByTs calls fn for each Record selected like ByTs
+// without loading all of them into memory.
+// If fn returns an error the iteration stops and the error is returned.
+func (t *Table) IterateByTs(ts string, q Querier, fn func(Record) error, opts ...func(*Table)) error {
+	fnc := "Table.IterateByTs"
+
+	t = t.withOptions(opts) // per call
+
+	err := t.byTsPrecs(ts, q) // preconditions
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	var xs []NameValue // empty
+	err = t.iterateByTsAndXs(ts, xs, q, fn)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+func (t *Table) byTsPrecs(ts string, q Querier) error {
 	fnc := "Table.byTsPrecs"
 
-	if GDb == nil {
+	if isNil(q) && GDb == nil {
 		err := Err{Fix: "LIVEDB:access needs at least database object"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -748,6 +879,10 @@ func (t *Table) byTsPrecs(ts string) error {
 		err := Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 
 	if t.Scan == nil {
 		err := Err{
@@ -762,24 +897,27 @@ func (t *Table) byTsPrecs(ts string) error {
 		return fmt.Errorf(fnc+":%w", err)
 	}
 
+	err = t.optionsPrecs() // WithLimit etc.
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
 	return nil
 }
 
 // Table.ByIDTs returns a Record and possibly an error.
-func (t *Table) ByIDTs(id int, ts string, tx *sql.Tx, opts ...func(*Table)) ([]Record, error) {
+func (t *Table) ByIDTs(id int, ts string, q Querier, opts ...func(*Table)) ([]Record, error) {
 	fnc := "Table.ByIDTs"
 
-	for _, opt := range opts { // non-default options
-		opt(t)
-	}
+	t = t.withOptions(opts) // per call
 
-	err := t.byIDTsPrecs(id, ts) // preconditions
+	err := t.byIDTsPrecs(id, ts, q) // preconditions
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
 
 	xs := []NameValue{{Name: "id", Value: id}}
-	recs, err := t.byTsAndXs(ts, xs, tx)
+	recs, err := t.byTsAndXs(ts, xs, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -787,10 +925,10 @@ func (t *Table) ByIDTs(id int, ts string, tx *sql.Tx, opts ...func(*Table)) ([]R
 	return recs, nil
 }
 
-func (t *Table) byIDTsPrecs(id int, ts string) error {
+func (t *Table) byIDTsPrecs(id int, ts string, q Querier) error {
 	fnc := "Table.byIDTsPrecs"
 
-	if GDb == nil {
+	if isNil(q) && GDb == nil {
 		err := Err{Fix: "LIVEDB:access needs at least database object"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -825,6 +963,10 @@ func (t *Table) byIDTsPrecs(id int, ts string) error {
 		err := Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 
 	if t.Scan == nil {
 		err := Err{
@@ -839,10 +981,280 @@ func (t *Table) byIDTsPrecs(id int, ts string) error {
 		return fmt.Errorf(fnc+":%w", err)
 	}
 
+	err = t.optionsPrecs() // WithLimit etc.
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+// Table.ByPeriod returns the Records valid at any time
+// within the period [from; until[ and possibly an error.
+// Results are ordered by ID and Begin.
+// Options WithLimit, WithAfter, WithOrderBy and WithFilter are supported.
+func (t *Table) ByPeriod(from, until string, q Querier, opts ...func(*Table)) ([]Record, error) {
+	fnc := "Table.ByPeriod"
+
+	t = t.withOptions(opts) // per call
+
+	err := t.byPeriodPrecs(from, until, q) // preconditions
+	if err != nil {
+		return []Record{}, fmt.Errorf(fnc+":%w", err)
+	}
+
+	s, sqlargs := t.byPeriodQuery(from, until)
+
+	recs, err := t.Query(s, sqlargs, t.Scan, q)
+	if err != nil {
+		return []Record{}, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return recs, nil
+}
+
+// Table.IterateByPeriod calls fn for each Record selected like ByPeriod
+// without loading all of them into memory.
+// If fn returns an error the iteration stops and the error is returned.
+func (t *Table) IterateByPeriod(from, until string, q Querier, fn func(Record) error, opts ...func(*Table)) error {
+	fnc := "Table.IterateByPeriod"
+
+	t = t.withOptions(opts) // per call
+
+	err := t.byPeriodPrecs(from, until, q) // preconditions
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	s, sqlargs := t.byPeriodQuery(from, until)
+
+	err = t.Iterate(s, sqlargs, t.Scan, q, fn)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
 	return nil
 }
 
-func (t *Table) byXs(xs []NameValue, tx *sql.Tx) ([]Record, error) {
+func (t *Table) byPeriodPrecs(from, until string, q Querier) error {
+	fnc := "Table.byPeriodPrecs"
+
+	if isNil(q) && GDb == nil {
+		err := Err{Fix: "LIVEDB:access needs at least database object"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if from == "" { // from must be provided
+		err := Err{
+			Fix: "LIVEDB:{{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "from"},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if until == "" { // until must be provided
+		err := Err{
+			Fix: "LIVEDB:{{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "until"},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if t.Name == "" {
+		err := Err{Fix: "LIVEDB:table name missing"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if t.Scan == nil {
+		err := Err{
+			Fix: "LIVEDB:{{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "t.Scan"},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	err = t.optionsPrecs() // WithLimit etc.
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+func (t *Table) byPeriodQuery(from, until string) (string, []interface{}) {
+	var buf bytes.Buffer
+	var put = buf.WriteString // write method
+
+	sqlargs := []interface{}{}
+	num := int(0)
+
+	t.putSelect(&buf)
+
+	if until == Now {
+		put(" where begin<" + FormatNow())
+	} else {
+		num++
+		put(" where begin<" + FormatTmsp(num))
+		sqlargs = append(sqlargs, until)
+	}
+	if from == Now {
+		put(" and (until is null or until>" + FormatNow() + ")")
+	} else {
+		num++
+		put(" and (until is null or until>" + FormatTmsp(num) + ")")
+		sqlargs = append(sqlargs, from)
+	}
+
+	sqlargs = t.putOptions(&buf, nil, num, sqlargs)
+
+	s := buf.String()
+
+	Log("s:", s)
+	Log("sqlargs:", sqlargs)
+
+	return s, sqlargs
+}
+
+// Table.History returns all Records of ID id and possibly an error.
+// Results are ordered by Begin.
+// Options WithLimit, WithAfter, WithOrderBy and WithFilter are supported.
+func (t *Table) History(id int, q Querier, opts ...func(*Table)) ([]Record, error) {
+	fnc := "Table.History"
+
+	t = t.withOptions(opts) // per call
+
+	err := t.historyPrecs(id, q) // preconditions
+	if err != nil {
+		return []Record{}, fmt.Errorf(fnc+":%w", err)
+	}
+
+	s, sqlargs := t.historyQuery(id)
+
+	recs, err := t.Query(s, sqlargs, t.Scan, q)
+	if err != nil {
+		return []Record{}, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return recs, nil
+}
+
+// Table.IterateHistory calls fn for each Record selected like History
+// without loading all of them into memory.
+// If fn returns an error the iteration stops and the error is returned.
+func (t *Table) IterateHistory(id int, q Querier, fn func(Record) error, opts ...func(*Table)) error {
+	fnc := "Table.IterateHistory"
+
+	t = t.withOptions(opts) // per call
+
+	err := t.historyPrecs(id, q) // preconditions
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	s, sqlargs := t.historyQuery(id)
+
+	err = t.Iterate(s, sqlargs, t.Scan, q, fn)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+func (t *Table) historyPrecs(id int, q Querier) error {
+	fnc := "Table.historyPrecs"
+
+	if isNil(q) && GDb == nil {
+		err := Err{Fix: "LIVEDB:access needs at least database object"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if id == 0 {
+		err := Err{
+			Fix: "LIVEDB:{{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "id"},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if t.Name == "" {
+		err := Err{Fix: "LIVEDB:table name missing"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if t.Scan == nil {
+		err := Err{
+			Fix: "LIVEDB:{{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "t.Scan"},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	err = t.optionsPrecs() // WithLimit etc.
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+func (t *Table) historyQuery(id int) (string, []interface{}) {
+	var buf bytes.Buffer
+	var put = buf.WriteString // write method
+
+	sqlargs := []interface{}{}
+	num := int(0)
+
+	t.putSelect(&buf)
+
+	num++
+	put(" where id=" + FormatAtt(num))
+	sqlargs = append(sqlargs, fmt.Sprint(id))
+
+	sqlargs = t.putOptions(&buf, nil, num, sqlargs)
+
+	s := buf.String()
+
+	Log("s:", s)
+	Log("sqlargs:", sqlargs)
+
+	return s, sqlargs
+}
+
+func (t *Table) byXs(xs []NameValue, q Querier) ([]Record, error) {
 	fnc := "Table.byXs"
 
 	var buf bytes.Buffer
@@ -854,15 +1266,15 @@ func (t *Table) byXs(xs []NameValue, tx *sql.Tx) ([]Record, error) {
 	put("select ")
 	for i, att := range StdAtts {
 		if i == 0 { // first one
-			put(att)
+			put(quote(att))
 			continue
 		}
-		put("," + att)
+		put("," + quote(att))
 	}
 	for _, att := range t.Atts {
-		put("," + att)
+		put("," + quote(att))
 	}
-	put(" from " + t.Name)
+	put(" from " + quote(t.Name))
 
 	for i, x := range xs {
 		if i == 0 {
@@ -871,13 +1283,13 @@ func (t *Table) byXs(xs []NameValue, tx *sql.Tx) ([]Record, error) {
 			put(" and ")
 		}
 		num++
-		put(x.Name + "=" + FormatAtt(num))
+		put(t.column(x.Name) + "=" + FormatAtt(num))
 		sqlargs = append(sqlargs, x.Value)
 	}
 
 	put(" order by ")
 	for _, x := range xs {
-		put(x.Name + ",")
+		put(t.column(x.Name) + ",")
 	}
 	put("id,begin;")
 
@@ -886,7 +1298,7 @@ func (t *Table) byXs(xs []NameValue, tx *sql.Tx) ([]Record, error) {
 	Log("s:", s)
 	Log("sqlargs:", sqlargs)
 
-	recs, err := t.Query(s, sqlargs, t.Scan, tx)
+	recs, err := t.Query(s, sqlargs, t.Scan, q)
 	if err != nil {
 		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
@@ -894,57 +1306,20 @@ func (t *Table) byXs(xs []NameValue, tx *sql.Tx) ([]Record, error) {
 	return recs, nil
 }
 
-func (t *Table) Query(s string, sqlargs []interface{}, scan ScanFunc, tx *sql.Tx) ([]Record, error) {
+// Table.Query executes a query and returns all its Records.
+// If scan is nil t.Scan is used.
+// For large results consider Table.Iterate or Table.Rows.
+func (t *Table) Query(s string, sqlargs []interface{}, scan ScanFunc, q Querier) ([]Record, error) {
 	fnc := "Table.Query"
 
-	rows := &sql.Rows{}
-	var err error
 	var recs []Record
 
-	if tx != nil {
-		rows, err = tx.Query(s, sqlargs...)
-	} else {
-		rows, err = GDb.Query(s, sqlargs...)
-	}
-	if err != nil {
-		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
-			Var: []struct {
-				Name  string
-				Value interface{}
-			}{
-				{"Query", strings.ReplaceAll(s, "%", "_")}, // better for fmt.Errorf
-			},
-		}
-		return []Record{}, fmt.Errorf(fnc+":%w:"+err.Error(), e)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		rec, err := t.Scan(rows) // call the given scan function
-		if err != nil {
-			e := Err{Fix: "LIVEDB:error at scan(rows) for query:{{.Query}}",
-				Var: []struct {
-					Name  string
-					Value interface{}
-				}{
-					{"Query", strings.ReplaceAll(s, "%", "_")}, // better for fmt.Errorf
-				},
-			}
-			return []Record{}, fmt.Errorf(fnc+":%w:"+err.Error(), e)
-		}
+	err := t.Iterate(s, sqlargs, scan, q, func(rec Record) error {
 		recs = append(recs, rec)
-	}
-	err = rows.Err()
+		return nil
+	})
 	if err != nil {
-		e := Err{Fix: "LIVEDB:error at rows.Next for query:{{.Query}}",
-			Var: []struct {
-				Name  string
-				Value interface{}
-			}{
-				{"Query", strings.ReplaceAll(s, "%", "_")}, // better for fmt.Errorf
-			},
-		}
-		return []Record{}, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		return []Record{}, fmt.Errorf(fnc+":%w", err)
 	}
 
 	return recs, nil
@@ -0,0 +1,280 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// tablesTab is the livedb metadata table registering livedb tables.
+const tablesTab = "livedb_tables"
+
+var tablesDefs = []string{
+	"tabname varchar(63) not null primary key",
+	"defs text not null", // one attribute definition per line
+	"version integer not null",
+	"origin varchar(100) not null",
+	"registered varchar(26) not null",
+}
+
+// TableInfo describes a registered livedb table.
+//
+// Tables generated by generatelivetab are registered with Origin
+// "generatelivetab <build time>", i.e. generator and generator version.
+// There is no granularity: all livedb tables keep Begin and Until
+// as timestamps of TmspLayout.
+type TableInfo struct {
+	Name       string
+	Defs       []string // attribute definitions
+	Atts       []string // attribute names
+	Version    int      // applied migration version
+	Origin     string   // see Table.Origin
+	Registered string   // timestamp
+}
+
+// Tables returns the livedb tables registered in table livedb_tables
+// ordered by name. Table.Create and Table.Migrate register tables,
+// Table.Rename and Table.Drop keep the registry up to date.
+//
+// Tables returns no tables if livedb_tables does not exist.
+func Tables(q Querier) ([]TableInfo, error) {
+	fnc := "Tables"
+
+	if isNil(q) && GDb == nil {
+		err := Err{Fix: "LIVEDB:access needs at least database object"}
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	ok, err := tableExists(tablesTab, q)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	s := "select tabname,defs,version,origin,registered from " + quote(tablesTab) +
+		" order by tabname;"
+
+	Log("s:", s)
+
+	rows, err := dbQuery(q, s)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+	defer rows.Close()
+
+	var infos []TableInfo
+	for rows.Next() {
+		var info TableInfo
+		var defs string
+		err = rows.Scan(&info.Name, &defs, &info.Version, &info.Origin, &info.Registered)
+		if err != nil {
+			e := Err{Fix: "LIVEDB:error at scan(rows) for query:{{.Query}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Query", s},
+				},
+			}
+			return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+		if defs != "" {
+			info.Defs = strings.Split(defs, "\n")
+		}
+		for _, def := range info.Defs {
+			info.Atts = append(info.Atts, strings.Fields(def)[0])
+		}
+		infos = append(infos, info)
+	}
+	err = rows.Err()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error at rows.Next for query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return infos, nil
+}
+
+// registered reports whether t is registered in livedb_tables.
+func (t *Table) registered(q Querier) (bool, error) {
+	fnc := "Table.registered"
+
+	ok, err := tableExists(tablesTab, q)
+	if err != nil {
+		return false, fmt.Errorf(fnc+":%w", err)
+	}
+	if !ok {
+		return false, nil
+	}
+
+	s := "select count(*) from " + quote(tablesTab) + " where tabname=" + FormatAtt(1) + ";"
+
+	Log("s:", s)
+	Log("sqlargs:", t.Name)
+
+	rows, err := dbQuery(q, s, t.Name)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return false, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+	defer rows.Close()
+
+	var n int
+	if rows.Next() {
+		err = rows.Scan(&n)
+		if err != nil {
+			e := Err{Fix: "LIVEDB:error at scan(rows) for query:{{.Query}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Query", s},
+				},
+			}
+			return false, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error at rows.Next for query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return false, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return n > 0, nil
+}
+
+// register registers t with its definitions and applied migration version,
+// replacing an existing entry. It creates table livedb_tables if needed.
+func (t *Table) register(q Querier) error {
+	fnc := "Table.register"
+
+	err := createMeta(tablesTab, tablesDefs, q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	version, err := t.appliedVersion(q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	err = t.unregister(q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	s := "insert into " + quote(tablesTab) + " (tabname,defs,version,origin,registered)" +
+		" values (" + FormatAtt(1) + "," + FormatAtt(2) + "," + FormatAtt(3) + "," +
+		FormatAtt(4) + "," + FormatNow() + ");"
+	sqlargs := []interface{}{t.Name, strings.Join(t.Defs, "\n"), version, t.Origin}
+
+	Log("s:", s)
+	Log("sqlargs:", sqlargs)
+
+	_, err = dbExec(q, s, sqlargs...)
+	if err != nil {
+		e := Err{
+			Fix: "LIVEDB:error inserting by:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return nil
+}
+
+// unregister removes t from livedb_tables.
+func (t *Table) unregister(q Querier) error {
+	fnc := "Table.unregister"
+
+	ok, err := tableExists(tablesTab, q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	s := "delete from " + quote(tablesTab) + " where tabname=" + FormatAtt(1) + ";"
+	err = tablesExec(q, s, "LIVEDB:error executing query:{{.Query}}", t.Name)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+// createMeta creates livedb metadata table name with definitions defs
+// unless it exists.
+func createMeta(name string, defs []string, q Querier) error {
+	fnc := "createMeta"
+
+	ok, err := tableExists(name, q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	if ok {
+		return nil
+	}
+
+	s := gDialect.createMeta(name, defs)
+
+	Log("s:", s)
+
+	_, err = dbExec(q, s)
+	if err != nil {
+		e := Err{
+			Fix: "LIVEDB:error creating table by:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for the registry of livedb tables.
+
+package livedb
+
+import (
+	"fmt"
+	"testing"
+)
+
+const rgtab = "tregistry"
+
+// tableInfo returns the registry entry of table name, if any.
+func tableInfo(t *testing.T, name string, q Querier) *TableInfo {
+	infos, err := Tables(q)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	for i := range infos {
+		if infos[i].Name == name {
+			return &infos[i]
+		}
+	}
+	return nil
+}
+
+// TestTables tests that Create, Migrate, Rename and Drop
+// keep the registry up to date.
+func TestTables(t *testing.T) {
+
+	creator := "TestTables"
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer Rollback(tx) // leave no test tables
+
+	tab := Table{Name: rgtab, Defs: []string{"a varchar(10)", "b integer"}, Origin: creator}
+	err = tab.Create(tx) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	info := tableInfo(t, rgtab, tx)
+	switch {
+	case info == nil:
+		t.Fatal("expected", rgtab, "to be registered")
+	case fmt.Sprint(info.Defs) != fmt.Sprint(tab.Defs),
+		fmt.Sprint(info.Atts) != "[a b]",
+		info.Version != 0,
+		info.Origin != creator,
+		info.Registered == "":
+		t.Error("unexpected registration", *info)
+	}
+
+	tab.Defs = append(tab.Defs, "c varchar(5)")
+	_, err = tab.Migrate(1, creator, false, false, tx) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	info = tableInfo(t, rgtab, tx)
+	if info == nil || info.Version != 1 || fmt.Sprint(info.Atts) != "[a b c]" {
+		t.Error("expected version 1 with attributes [a b c], got", info)
+	}
+
+	err = tab.Rename(rgtab+"2", tx) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if tableInfo(t, rgtab, tx) != nil || tableInfo(t, rgtab+"2", tx) == nil {
+		t.Error("expected registration to follow rename")
+	}
+
+	err = tab.Drop(tx) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if tableInfo(t, rgtab+"2", tx) != nil {
+		t.Error("expected registration to be dropped")
+	}
+}
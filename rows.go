@@ -0,0 +1,179 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// Rows is a cursor over the Records of a query.
+// Unlike Table.Query it holds only one Record at a time,
+// so it is suitable for exports and batch jobs on large tables.
+//
+// Use it like *sql.Rows:
+//
+//	rows, err := t.Rows(s, sqlargs, scan, q)
+//	...
+//	defer rows.Close()
+//	for rows.Next() {
+//		rec := rows.Record()
+//		...
+//	}
+//	err = rows.Err()
+type Rows struct {
+	rows *sql.Rows
+	scan ScanFunc
+	s    string // query
+	rec  Record
+	err  error
+}
+
+// Next prepares the next Record for Record.
+// It returns false when there are no more Records or an error occurred;
+// Err tells the difference.
+func (r *Rows) Next() bool {
+	fnc := "Rows.Next"
+
+	if r.err != nil {
+		return false
+	}
+
+	if !r.rows.Next() {
+		err := r.rows.Err()
+		if err != nil {
+			e := Err{Fix: "LIVEDB:error at rows.Next for query:{{.Query}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Query", strings.ReplaceAll(r.s, "%", "_")}, // better for fmt.Errorf
+				},
+			}
+			r.err = fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+		return false
+	}
+
+	rec, err := r.scan(r.rows) // call the given scan function
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error at scan(rows) for query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", strings.ReplaceAll(r.s, "%", "_")}, // better for fmt.Errorf
+			},
+		}
+		r.err = fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		return false
+	}
+	r.rec = rec
+
+	return true
+}
+
+// Record returns the current Record.
+func (r *Rows) Record() Record {
+	return r.rec
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Close closes the cursor. It may be called more than once.
+func (r *Rows) Close() error {
+	fnc := "Rows.Close"
+
+	err := r.rows.Close()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error closing rows"}
+		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return nil
+}
+
+// Table.Rows executes a query and returns a cursor over its Records.
+// If scan is nil t.Scan is used.
+// The caller must close the cursor.
+func (t *Table) Rows(s string, sqlargs []interface{}, scan ScanFunc, q Querier) (*Rows, error) {
+	fnc := "Table.Rows"
+
+	if scan == nil {
+		scan = t.Scan
+	}
+	if scan == nil {
+		err := Err{
+			Fix: "LIVEDB:{{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "scan"},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	rows, err := dbQuery(q, s, sqlargs...)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", strings.ReplaceAll(s, "%", "_")}, // better for fmt.Errorf
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return &Rows{rows: rows, scan: scan, s: s}, nil
+}
+
+// Table.Iterate executes a query and calls fn for each Record.
+// If fn returns an error the iteration stops and the error is returned.
+// If scan is nil t.Scan is used.
+func (t *Table) Iterate(s string, sqlargs []interface{}, scan ScanFunc, q Querier, fn func(Record) error) error {
+	fnc := "Table.Iterate"
+
+	if fn == nil {
+		err := Err{
+			Fix: "LIVEDB:{{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "fn"},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	rows, err := t.Rows(s, sqlargs, scan, q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = fn(rows.Record())
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for streaming reads.
+
+package livedb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestIterateByTs tests that IterateByTs delivers the same Records as ByTs.
+func TestIterateByTs(t *testing.T) {
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	recs, err := tab.ByTs(Now, nil)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if len(recs) == 0 {
+		t.Skip("no records in", tetab) // filled by TestStart etc.
+	}
+
+	type iterateTest struct {
+		stop int // fn returns error at this call (0: never)
+		//
+		want int // expected calls
+		ok   bool
+	}
+	iterateTests := []iterateTest{
+		{0, len(recs), true},
+		{1, 1, false}, // stopped by fn
+	}
+
+	for i, v := range iterateTests {
+		calls := 0
+		fn := func(rec Record) error {
+			if rec.Std.ID != recs[calls].Std.ID || rec.Std.Begin != recs[calls].Std.Begin {
+				t.Error("#"+fmt.Sprintf("%d", i+1), "record", calls+1, "differs from ByTs")
+			}
+			calls++
+			if calls == v.stop {
+				return errors.New("ENTWICKLERTEST")
+			}
+			return nil
+		}
+
+		err = tab.IterateByTs(Now, nil, fn) // <------- ACTION
+		switch {
+		case calls != v.want:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.want, "calls, got", calls)
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestIterateHistory tests that IterateHistory delivers the same Records as History.
+func TestIterateHistory(t *testing.T) {
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	recs, err := tab.ByTs(Now, nil)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if len(recs) == 0 {
+		t.Skip("no records in", tetab) // filled by TestStart etc.
+	}
+
+	for i, rec := range recs {
+		hist, err := tab.History(rec.Std.ID, nil)
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal("#"+fmt.Sprintf("%d", i+1), err)
+		}
+
+		calls := 0
+		fn := func(h Record) error {
+			if calls >= len(hist) || h.Std.Pkey != hist[calls].Std.Pkey {
+				t.Error("#"+fmt.Sprintf("%d", i+1), "record", calls+1, "differs from History")
+			}
+			calls++
+			return nil
+		}
+
+		err = tab.IterateHistory(rec.Std.ID, nil, fn) // <------- ACTION
+		switch {
+		case err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case calls != len(hist):
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", len(hist), "calls, got", calls)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestRows tests the cursor returned by Table.Rows.
+func TestRows(t *testing.T) {
+
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+	s, sqlargs := tab.byTsAndXsQuery(Now, nil)
+
+	recs, err := tab.Query(s, sqlargs, nil, nil)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	rows, err := tab.Rows(s, sqlargs, nil, nil) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		if rows.Record().Std.Pkey != recs[n].Std.Pkey {
+			t.Error("record", n+1, "differs from Query")
+		}
+		n++
+	}
+	err = rows.Err()
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Error(err)
+	}
+	if n != len(recs) {
+		t.Error("expected", len(recs), "records, got", n)
+	}
+}
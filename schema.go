@@ -0,0 +1,336 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// Column describes a column of a database table.
+type Column struct {
+	Name     string
+	Type     string // as reported by the database, e.g. "varchar", "character varying"
+	Length   int    // maximum length of character types, 0 otherwise
+	Nullable bool
+}
+
+// Index describes an index of a database table.
+type Index struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// Table.Exists reports whether the livedb table and its ID-table exist.
+// It fails if only one of them exists.
+//
+// Table.Exists reads the database catalog, so it can be used
+// within transactions on every database.
+func (t *Table) Exists(q Querier) (bool, error) {
+	fnc := "Table.Exists"
+
+	err := t.schemaPrecs(q) // preconditions
+	if err != nil {
+		return false, fmt.Errorf(fnc+":%w", err)
+	}
+
+	ok, err := t.exists(q)
+	if err != nil {
+		return false, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return ok, nil
+}
+
+// Table.Columns returns the columns of the livedb table in order of definition.
+// It returns no columns if the table does not exist.
+func (t *Table) Columns(q Querier) ([]Column, error) {
+	fnc := "Table.Columns"
+
+	err := t.schemaPrecs(q) // preconditions
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	cols, err := columns(t.Name, q)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return cols, nil
+}
+
+// Table.Indexes returns the indexes of the livedb table ordered by name.
+// It returns no indexes if the table does not exist.
+func (t *Table) Indexes(q Querier) ([]Index, error) {
+	fnc := "Table.Indexes"
+
+	err := t.schemaPrecs(q) // preconditions
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	idxs, err := indexes(t.Name, q)
+	if err != nil {
+		return nil, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return idxs, nil
+}
+
+func (t *Table) schemaPrecs(q Querier) error {
+	fnc := "Table.schemaPrecs"
+
+	if isNil(q) && GDb == nil {
+		err := Err{Fix: "LIVEDB:access needs at least database object"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if t.Name == "" {
+		err := Err{Fix: "LIVEDB:table name missing"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	err := t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+func (t *Table) exists(q Querier) (bool, error) {
+	fnc := "Table.exists"
+
+	ok1, err := tableExists(t.Name, q)
+	if err != nil {
+		return false, fmt.Errorf(fnc+":%w", err)
+	}
+	ok2, err := tableExists(t.Name+"id", q)
+	if err != nil {
+		return false, fmt.Errorf(fnc+":%w", err)
+	}
+
+	switch {
+	case ok1 && !ok2:
+		err := Err{
+			Fix: "LIVEDB:table {{.Name}} exists, table {{.Nam2}} is missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", t.Name},
+				{"Nam2", t.Name + "id"},
+			},
+		}
+		return false, fmt.Errorf(fnc+":%w", err)
+	case ok2 && !ok1:
+		err := Err{
+			Fix: "LIVEDB:table {{.Name}} exists, table {{.Nam2}} is missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", t.Name + "id"},
+				{"Nam2", t.Name},
+			},
+		}
+		return false, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return ok1 && ok2, nil
+}
+
+// tableExists reports whether the database catalog contains table name.
+func tableExists(name string, q Querier) (bool, error) {
+	fnc := "tableExists"
+
+	s := sqlTableExists
+
+	Log("s:", s)
+	Log("sqlargs:", name)
+
+	rows, err := dbQuery(q, s, name)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return false, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+	defer rows.Close()
+
+	var n int
+	if rows.Next() {
+		err = rows.Scan(&n)
+		if err != nil {
+			e := Err{Fix: "LIVEDB:error at scan(rows) for query:{{.Query}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Query", s},
+				},
+			}
+			return false, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error at rows.Next for query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return false, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return n > 0, nil
+}
+
+// columns reads the columns of table name from the database catalog.
+func columns(name string, q Querier) ([]Column, error) {
+	fnc := "columns"
+
+	s := sqlColumns
+
+	Log("s:", s)
+	Log("sqlargs:", name)
+
+	rows, err := dbQuery(q, s, name)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+	defer rows.Close()
+
+	var cols []Column
+	for rows.Next() {
+		var col Column
+		var nullable string
+		err = rows.Scan(&col.Name, &col.Type, &col.Length, &nullable)
+		if err != nil {
+			e := Err{Fix: "LIVEDB:error at scan(rows) for query:{{.Query}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Query", s},
+				},
+			}
+			return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+		col.Nullable = nullable == "YES"
+		col.Type, col.Length = splitType(col.Type, col.Length)
+		cols = append(cols, col)
+	}
+	err = rows.Err()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error at rows.Next for query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return cols, nil
+}
+
+// splitType splits a declared type like "varchar(10)" into type and length
+// (Sqlite reports declared types only).
+func splitType(typ string, length int) (string, int) {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+
+	i := strings.Index(typ, "(")
+	if i < 0 || !strings.HasSuffix(typ, ")") {
+		return typ, length
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(typ[i+1 : len(typ)-1]))
+	if err != nil { // e.g. decimal(10,2)
+		return typ, length
+	}
+	return strings.TrimSpace(typ[:i]), n
+}
+
+// indexes reads the indexes of table name from the database catalog.
+func indexes(name string, q Querier) ([]Index, error) {
+	fnc := "indexes"
+
+	s := sqlIndexes
+
+	Log("s:", s)
+	Log("sqlargs:", name)
+
+	rows, err := dbQuery(q, s, name)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+	defer rows.Close()
+
+	var idxs []Index
+	for rows.Next() { // one row per index column
+		var idxName, col string
+		var unique int
+		err = rows.Scan(&idxName, &unique, &col)
+		if err != nil {
+			e := Err{Fix: "LIVEDB:error at scan(rows) for query:{{.Query}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Query", s},
+				},
+			}
+			return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+		if len(idxs) == 0 || idxs[len(idxs)-1].Name != idxName {
+			idxs = append(idxs, Index{Name: idxName, Unique: unique != 0})
+		}
+		idxs[len(idxs)-1].Columns = append(idxs[len(idxs)-1].Columns, col)
+	}
+	err = rows.Err()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error at rows.Next for query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return idxs, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for schema introspection.
+
+package livedb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type existsTest struct {
+	name string
+	//
+	exists bool
+	ok     bool
+}
+
+// TestExists tests Table.Exists within a transaction.
+func TestExists(t *testing.T) {
+
+	existsTests := []existsTest{
+		{tetab, true, true},
+		{"nosuch", false, true},
+		{tetab + "id", false, false}, // ttestid exists, ttestidid is missing
+		{"no such", false, false},
+	}
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer Rollback(tx)
+
+	for i, v := range existsTests {
+		tab := Table{Name: v.name}
+
+		ok, err := tab.Exists(tx) // <------- ACTION
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		case ok != v.exists:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.exists, "got", ok)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+
+	// transaction still usable
+	tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+	_, err = tab.ByTs(Now, tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Error(err)
+	}
+}
+
+// TestColumns tests that Table.Columns reports all attributes.
+func TestColumns(t *testing.T) {
+
+	tab := Table{Name: tetab}
+
+	cols, err := tab.Columns(nil) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	want := append(append([]string{}, StdAtts...), teAtts...)
+	if len(cols) != len(want) {
+		t.Fatal("expected", len(want), "columns, got", len(cols))
+	}
+	for i, col := range cols {
+		if col.Name != want[i] {
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected column", want[i], "got", col.Name)
+		}
+		switch col.Name {
+		case "str1":
+			if col.Length != 10 || !col.Nullable {
+				t.Error("#"+fmt.Sprintf("%d", i+1), "expected nullable length 10, got", col)
+			}
+		case "str2":
+			if col.Length != 10 || col.Nullable {
+				t.Error("#"+fmt.Sprintf("%d", i+1), "expected not null length 10, got", col)
+			}
+		}
+	}
+
+	tab = Table{Name: "nosuch"}
+	cols, err = tab.Columns(nil) // <------- ACTION
+	if err != nil || len(cols) != 0 {
+		t.Error("expected no columns, got", cols, err)
+	}
+}
+
+// TestIndexes tests that Table.Indexes reports the livedb indexes.
+func TestIndexes(t *testing.T) {
+
+	tab := Table{Name: tetab}
+
+	idxs, err := tab.Indexes(nil) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	want := map[string]string{ // name: unique columns
+		tetab + "idxidbegin": "true id,begin",
+		tetab + "idxiduntil": "false id,until",
+	}
+	for _, idx := range idxs {
+		got := fmt.Sprint(idx.Unique) + " " + strings.Join(idx.Columns, ",")
+		w, ok := want[idx.Name]
+		if !ok {
+			continue // e.g. primary key
+		}
+		if got != w {
+			t.Error("index", idx.Name, "expected", w, "got", got)
+		}
+		delete(want, idx.Name)
+	}
+	for name := range want {
+		t.Error("index", name, "missing")
+	}
+}
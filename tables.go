@@ -0,0 +1,429 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"database/sql"
+	"fmt"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// Table.Drop drops the livedb table with its ID-table and their indexes
+// (and sequences). Its registration and records of applied migrations are deleted.
+// Nothing happens if neither table exists.
+//
+// NOTE: Mysql commits a transaction implicitly on drop table.
+func (t *Table) Drop(q Querier) error {
+	fnc := "Table.Drop"
+
+	err := t.tablesPrecs(q) // preconditions
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	for _, name := range []string{t.Name, t.Name + "id"} {
+		ok, err := tableExists(name, q)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+		if !ok {
+			continue // tolerate half created pairs
+		}
+		err = tablesExec(q, "drop table "+quote(name)+";",
+			"LIVEDB:error dropping table by:{{.Query}}")
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	err = t.unregister(q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	ok, err := tableExists(migrationsTab, q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	if ok {
+		s := "delete from " + quote(migrationsTab) + " where tabname=" + FormatAtt(1) + ";"
+		err = tablesExec(q, s, "LIVEDB:error executing query:{{.Query}}", t.Name)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	return nil
+}
+
+// Table.Rename renames the livedb table with its ID-table, their indexes
+// and (Postgres) sequences to newName. Its registration and records
+// of applied migrations follow.
+// On success t.Name is newName.
+//
+// NOTE: Mysql commits a transaction implicitly on rename table.
+func (t *Table) Rename(newName string, q Querier) error {
+	fnc := "Table.Rename"
+
+	err := t.renamePrecs(newName, q) // preconditions
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	ok, err := t.exists(q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	if !ok {
+		err := Err{
+			Fix: "LIVEDB:table {{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", t.Name},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	neu := Table{Name: newName}
+	ok, err = neu.exists(q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	if ok {
+		err = Err{
+			Fix: "LIVEDB:table {{.Name}} exists already",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", newName},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
+	}
+
+	err = t.rename(newName, q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+func (t *Table) renamePrecs(newName string, q Querier) error {
+	fnc := "Table.renamePrecs"
+
+	err := t.tablesPrecs(q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if newName == "" {
+		err := Err{
+			Fix: "LIVEDB:{{.Name}} missing",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Name", "newName"},
+			},
+		}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	neu := Table{Name: newName}
+	err = neu.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+func (t *Table) rename(newName string, q Querier) error {
+	fnc := "Table.rename"
+
+	old := t.Name
+	fix := "LIVEDB:error renaming by:{{.Query}}"
+
+	stmts := []string{
+		"alter table " + quote(old) + " rename to " + quote(newName) + ";",
+		"alter table " + quote(old+"id") + " rename to " + quote(newName+"id") + ";",
+	}
+	stmts = append(stmts, renameSequences(old, newName)...)
+	for _, s := range stmts {
+		err := tablesExec(q, s, fix)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	t.Name = newName
+
+	for _, suffix := range []string{"idxidbegin", "idxiduntil"} {
+		s := renameIndex(newName, old+suffix, newName+suffix)
+		if s != "" {
+			err := tablesExec(q, s, fix)
+			if err != nil {
+				return fmt.Errorf(fnc+":%w", err)
+			}
+			continue
+		}
+
+		// index cannot be renamed: recreate it
+		err := tablesExec(q, "drop index "+quote(old+suffix)+";", fix)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+		if suffix == "idxidbegin" {
+			err = t.createIndexIDBegin(q)
+		} else {
+			err = t.createIndexIDUntil(q)
+		}
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	for _, meta := range []string{migrationsTab, tablesTab} {
+		ok, err := tableExists(meta, q)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+		if !ok {
+			continue
+		}
+		s := "update " + quote(meta) + " set tabname=" + FormatAtt(1) +
+			" where tabname=" + FormatAtt(2) + ";"
+		err = tablesExec(q, s, "LIVEDB:error executing query:{{.Query}}", newName, old)
+		if err != nil {
+			return fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	return nil
+}
+
+func (t *Table) tablesPrecs(q Querier) error {
+	fnc := "Table.tablesPrecs"
+
+	if isNil(q) && GDb == nil {
+		err := Err{Fix: "LIVEDB:access needs at least database object"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	err := readOnlyPrecs(q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if t.Name == "" {
+		err := Err{Fix: "LIVEDB:table name missing"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	err = t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+// tablesExec executes statement s, reporting errors with fix.
+func tablesExec(q Querier, s, fix string, sqlargs ...interface{}) error {
+	fnc := "tablesExec"
+
+	Log("s:", s)
+	Log("sqlargs:", sqlargs)
+
+	_, err := dbExec(q, s, sqlargs...)
+	if err != nil {
+		e := Err{
+			Fix: fix,
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return nil
+}
+
+// Table.PurgeFuture deletes all records which become valid after ts
+// and returns their number.
+//
+// A predecessor valid at ts takes over Until of the last deleted record
+// of its ID; it is no longer terminated if that is open.
+// IDs without such predecessor vanish.
+func (t *Table) PurgeFuture(ts, creator string, q Querier) (n int, err error) {
+	fnc := "Table.PurgeFuture"
+
+	err = t.purgeFuturePrecs(ts, creator, q) // preconditions
+	if err != nil {
+		return 0, fmt.Errorf(fnc+":%w", err)
+	}
+
+	n, err = t.purgeFuture(ts, creator, q)
+	if err != nil {
+		return 0, fmt.Errorf(fnc+":%w", err)
+	}
+
+	return n, nil
+}
+
+func (t *Table) purgeFuturePrecs(ts, creator string, q Querier) error {
+	fnc := "Table.purgeFuturePrecs"
+
+	err := writePrecs(ts, creator, q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	if t.Name == "" { // t.Name must be provided
+		err = Err{Fix: "LIVEDB:table name missing"}
+		return fmt.Errorf(fnc+":%w", err)
+	}
+	err = t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
+
+	return nil
+}
+
+// future describes the future records of one ID.
+type future struct {
+	id    int
+	begin string         // begin of the first future record
+	until sql.NullString // until of the last future record
+}
+
+func (t *Table) purgeFuture(ts, creator string, q Querier) (int, error) {
+	fnc := "Table.purgeFuture"
+
+	ts, err := handleTs(ts)
+	if err != nil {
+		return 0, fmt.Errorf(fnc+":%w", err)
+	}
+
+	var after string // where clause
+	var sqlargs []interface{}
+	if ts == Now {
+		after = " where begin>" + FormatNow()
+	} else {
+		after = " where begin>" + FormatTmsp(1)
+		sqlargs = append(sqlargs, ts)
+	}
+
+	futures, err := t.futures(after, sqlargs, q)
+	if err != nil {
+		return 0, fmt.Errorf(fnc+":%w", err)
+	}
+
+	for _, f := range futures { // bookkeeping for predecessors
+		var s string
+		var args []interface{}
+		if f.until.Valid {
+			s = "update " + quote(t.Name) + " set until=" + FormatAtt(1) +
+				",ended=" + FormatNow() + ",endedby=" + FormatAtt(2) +
+				" where id=" + FormatAtt(3) + " and until=" + FormatAtt(4) + ";"
+			args = []interface{}{f.until.String, creator, fmt.Sprint(f.id), f.begin}
+		} else {
+			s = "update " + quote(t.Name) + " set until=null,ended=null,endedby=null" +
+				" where id=" + FormatAtt(1) + " and until=" + FormatAtt(2) + ";"
+			args = []interface{}{fmt.Sprint(f.id), f.begin}
+		}
+		err = tablesExec(q, s, "LIVEDB:error executing query:{{.Query}}", args...)
+		if err != nil {
+			return 0, fmt.Errorf(fnc+":%w", err)
+		}
+	}
+
+	s := "delete from " + quote(t.Name) + after + ";"
+
+	Log("s:", s)
+	Log("sqlargs:", sqlargs)
+
+	r, err := dbExec(q, s, sqlargs...)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error executing delete"}
+		return 0, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	n, err := r.RowsAffected()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error getting rows affected"}
+		return 0, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return int(n), nil
+}
+
+// futures reads begin and until of the future records
+// and returns them condensed per ID.
+func (t *Table) futures(after string, sqlargs []interface{}, q Querier) ([]future, error) {
+	fnc := "Table.futures"
+
+	s := "select id,begin,until from " + quote(t.Name) + after + " order by id,begin;"
+
+	Log("s:", s)
+	Log("sqlargs:", sqlargs)
+
+	rows, err := dbQuery(q, s, sqlargs...)
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error executing query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+	defer rows.Close()
+
+	var futures []future
+	for rows.Next() {
+		var f future
+		err = rows.Scan(&f.id, &f.begin, &f.until)
+		if err != nil {
+			e := Err{Fix: "LIVEDB:error at scan(rows) for query:{{.Query}}",
+				Var: []struct {
+					Name  string
+					Value interface{}
+				}{
+					{"Query", s},
+				},
+			}
+			return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+		}
+		if len(futures) > 0 && futures[len(futures)-1].id == f.id {
+			futures[len(futures)-1].until = f.until // follower
+			continue
+		}
+		futures = append(futures, f)
+	}
+	err = rows.Err()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:error at rows.Next for query:{{.Query}}",
+			Var: []struct {
+				Name  string
+				Value interface{}
+			}{
+				{"Query", s},
+			},
+		}
+		return nil, fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	return futures, nil
+}
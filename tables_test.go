@@ -0,0 +1,206 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for dropping, renaming and purging tables.
+
+package livedb
+
+import (
+	"testing"
+)
+
+const (
+	drtab = "tdrop"
+	rntab = "trenamed"
+)
+
+// TestRenameDrop tests renaming a table pair with its indexes
+// and dropping it afterwards.
+func TestRenameDrop(t *testing.T) {
+
+	creator := "TestRenameDrop"
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer func() {
+		e := Commit(tx) // end transaction
+		if e != nil {
+			e = translate(e, lang) // ******** l10n ********
+			t.Log(e)
+		}
+	}()
+
+	tab := Table{Name: drtab, Defs: []string{"a varchar(10)"}}
+	err = tab.Create(tx)
+	if err == nil {
+		_, err = tab.Migrate(1, creator, false, false, tx)
+	}
+	if err == nil {
+		_, err = tab.NewID(creator, tx) // sequences in use
+	}
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	err = tab.Rename("no such", tx) // <------- ACTION
+	if err == nil {
+		t.Error("expected error for invalid name, got ok")
+	}
+	err = tab.Rename(tetab, tx) // <------- ACTION
+	if err == nil {
+		t.Error("expected error for existing table, got ok")
+	}
+
+	err = tab.Rename(rntab, tx) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if tab.Name != rntab {
+		t.Error("expected name", rntab, "got", tab.Name)
+	}
+
+	old := Table{Name: drtab}
+	ok, err := old.Exists(tx)
+	if err != nil || ok {
+		t.Error("expected", drtab, "to be gone, got", ok, err)
+	}
+	ok, err = tab.Exists(tx)
+	if err != nil || !ok {
+		t.Error("expected", rntab, "to exist, got", ok, err)
+	}
+
+	idxs, err := tab.Indexes(tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	want := map[string]bool{rntab + "idxidbegin": true, rntab + "idxiduntil": true}
+	for _, idx := range idxs {
+		delete(want, idx.Name)
+	}
+	for name := range want {
+		t.Error("index", name, "missing")
+	}
+
+	_, err = tab.NewID(creator, tx) // sequences follow
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Error(err)
+	}
+	_, err = tab.Migrate(1, creator, true, false, tx) // migrations follow
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Error(err)
+	}
+	applied, err := tab.appliedVersion(tx)
+	if err != nil || applied != 1 {
+		t.Error("expected applied version 1, got", applied, err)
+	}
+
+	err = tab.Drop(tx) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	ok, err = tab.Exists(tx)
+	if err != nil || ok {
+		t.Error("expected", rntab, "to be gone, got", ok, err)
+	}
+	applied, err = tab.appliedVersion(tx)
+	if err != nil || applied != 0 {
+		t.Error("expected no applied version, got", applied, err)
+	}
+
+	err = tab.Drop(tx) // <------- ACTION: nothing to do
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Error(err)
+	}
+}
+
+// TestPurgeFuture tests deleting future records
+// and restoring Until of their predecessors.
+func TestPurgeFuture(t *testing.T) {
+
+	creator := "TestPurgeFuture"
+	future1 := "2998-01-01 00:00:00"
+	future2 := "2999-01-01 00:00:00"
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer Rollback(tx) // leave no test records
+
+	tab := Table{Name: tetab, Atts: teAtts, Vals: teVals, Scan: teScan}
+
+	// ID 1: now, changed in future1 and future2
+	tab.New = Record{Idv: Te{str2: "purge1"}}
+	id1, err := tab.NewID(creator, tx)
+	if err == nil {
+		_, err = tab.Start(id1, Now, creator, tx)
+	}
+	for _, ts := range []string{future1, future2} {
+		if err != nil {
+			break
+		}
+		var recs []Record
+		recs, err = tab.ByIDTs(id1, ts, tx)
+		if err == nil {
+			tab.Old = recs[0]
+			tab.New = Record{Idv: Te{str2: "purge1 " + ts}}
+			_, err = tab.Change(ts, creator, tx)
+		}
+	}
+	// ID 2: starts in future1
+	var id2 int
+	if err == nil {
+		tab.New = Record{Idv: Te{str2: "purge2"}}
+		id2, err = tab.NewID(creator, tx)
+	}
+	if err == nil {
+		_, err = tab.Start(id2, future1, creator, tx)
+	}
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+
+	_, err = tab.PurgeFuture(Now, creator, nil) // <------- ACTION
+	if err == nil {
+		t.Error("expected error without transaction, got ok")
+	}
+
+	n, err := tab.PurgeFuture(Now, creator, tx) // <------- ACTION
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if n < 3 {
+		t.Error("expected at least 3 deleted records, got", n)
+	}
+
+	recs, err := tab.History(id1, tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if len(recs) != 1 || recs[0].Std.Until != "" || recs[0].Std.EndedBy != "" {
+		t.Error("expected one open record for ID", id1, "got", recs)
+	}
+	recs, err = tab.History(id2, tx)
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	if len(recs) != 0 {
+		t.Error("expected no record for ID", id2, "got", recs)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+package livedb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+// RetryOpts is a type used by function RunInTx.
+// Zero values are replaced by defaults.
+type RetryOpts struct {
+	Attempts int            // maximum number of attempts (default 3)
+	Backoff  time.Duration  // wait before the first retry, doubled for every further retry (default 10ms)
+	TxOpts   *sql.TxOptions // transaction options, e.g. &SerializableWrite
+}
+
+const (
+	defaultAttempts = 3
+	defaultBackoff  = 10 * time.Millisecond
+)
+
+// RunInTx begins a transaction, calls fn with it and commits it.
+//
+// If fn or the commit fails because the database could not serialize
+// the transaction (e.g. serialization failure, deadlock, locked database),
+// the transaction is rolled back and tried again after a backoff.
+// Every other error of fn rolls back the transaction and is returned.
+// RunInTx reports the number of attempts made.
+//
+// Database db may be nil, GDb is used then.
+func RunInTx(ctx context.Context, db *sql.DB, fn func(*Tx) error, opts *RetryOpts) (attempts int, err error) {
+	fnc := "RunInTx"
+
+	if db == nil {
+		db = GDb
+	}
+	if db == nil {
+		err = Err{Fix: "LIVEDB:access needs at least database object"}
+		return 0, fmt.Errorf(fnc+":%w", err)
+	}
+
+	limit, backoff := defaultAttempts, defaultBackoff
+	if opts != nil && opts.Attempts > 0 {
+		limit = opts.Attempts
+	}
+	if opts != nil && opts.Backoff > 0 {
+		backoff = opts.Backoff
+	}
+
+	for attempts = 1; ; attempts++ {
+		var again bool
+
+		again, err = runOnce(ctx, db, fn, opts)
+		if err == nil {
+			return attempts, nil
+		}
+		if !again || attempts >= limit {
+			return attempts, fmt.Errorf(fnc+":%w", err)
+		}
+
+		Log("retry transaction after attempt", attempts)
+
+		select {
+		case <-ctx.Done():
+			e := Err{Fix: "LIVEDB:transaction retry canceled"}
+			return attempts, fmt.Errorf(fnc+":%w:"+ctx.Err().Error(), e)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// runOnce does one attempt of RunInTx and tells if it is worth another.
+func runOnce(ctx context.Context, db *sql.DB, fn func(*Tx) error, opts *RetryOpts) (again bool, err error) {
+	fnc := "runOnce"
+
+	var txOpts *sql.TxOptions
+	if opts != nil {
+		txOpts = opts.TxOpts
+	}
+
+	tx, err := beginTx(ctx, db, txOpts)
+	if err != nil {
+		return retryable(err), fmt.Errorf(fnc+":%w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = fn(tx)
+	if err != nil {
+		e := tx.Rollback()
+		if e != nil {
+			Log("rollback transaction failed:", e)
+		} else {
+			Log("rollback transaction")
+		}
+		return retryable(err), fmt.Errorf(fnc+":%w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		e := Err{Fix: "LIVEDB:commit transaction failed"}
+		return retryable(err), fmt.Errorf(fnc+":%w:"+err.Error(), e)
+	}
+
+	Log("commit transaction")
+
+	return false, nil
+}
+
+// Tx is a transaction begun by BeginTx or RunInTx.
+// It is a Querier; the methods of *sql.Tx (Commit, Rollback etc.)
+// can be called on it, and tx.Tx can be passed to Commit and Rollback.
+//
+// A Tx knows whether it is read-only, so write access is refused
+// even where the database driver ignores read-only (Sqlite).
+type Tx struct {
+	*sql.Tx
+	ctx      context.Context // see BeginTx
+	readOnly bool
+}
+
+// ReadOnly reports whether tx has been begun read-only.
+func (tx *Tx) ReadOnly() bool {
+	return tx != nil && tx.readOnly
+}
+
+// readOnlyPrecs refuses read-only transactions for write access.
+// Only a *Tx is known as read-only; other Queriers are left
+// to the database.
+func readOnlyPrecs(q Querier) error {
+	fnc := "readOnlyPrecs"
+
+	_, q = unwrap(q) // see WithContext
+	if tx, ok := q.(*Tx); ok && tx.ReadOnly() {
+		err := Err{Fix: "LIVEDB:write access refused in read-only transaction"}
+		return fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2021 Hans-Werner Heinzen. All rights reserved.
+// Use of this source code is governed by a license
+// that can be found in the LICENSE file.
+
+// Tests for transaction handling.
+
+package livedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/hwheinzen/stringl10n/mistake"
+)
+
+type runInTxTest struct {
+	fails    int   // number of failing calls of fn
+	failErr  error // error of failing calls
+	attempts int   // limit
+	//
+	want int // expected attempts
+	ok   bool
+}
+
+// TestRunInTx tests retries of transactions.
+func TestRunInTx(t *testing.T) {
+
+	creator := "TestRunInTx"
+
+	runInTxTests := []runInTxTest{
+		{0, nil, 3, 1, true},                           // OK at once
+		{2, retryableErr, 3, 3, true},                  // OK after retries
+		{3, retryableErr, 3, 3, false},                 // too many retries
+		{1, errors.New("ENTWICKLERTEST"), 3, 1, false}, // not retryable
+	}
+
+	for i, v := range runInTxTests {
+		tab := Table{Name: tetab}
+		calls := 0
+
+		fn := func(tx *Tx) error {
+			calls++
+			_, err := tab.newID(creator, tx)
+			if err != nil {
+				return err
+			}
+			if calls <= v.fails {
+				return v.failErr
+			}
+			return nil
+		}
+
+		opts := &RetryOpts{Attempts: v.attempts, Backoff: time.Millisecond}
+		n, err := RunInTx(context.Background(), nil, fn, opts) // <------- ACTION
+		switch {
+		case n != v.want:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected", v.want, "attempts, got", n)
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
+
+// TestRetryable tests that driver messages wrapped into livedb errors
+// are recognized as retryable.
+func TestRetryable(t *testing.T) {
+
+	e := Err{Fix: "LIVEDB:ENTWICKLERTEST"}
+	for i, msg := range retryableMsgs {
+		err := fmt.Errorf("TestRetryable:%w:"+msg, e)
+		if !retryable(err) { // <------- ACTION
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected retryable:", msg)
+			continue
+		}
+		t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+	}
+	if retryable(errors.New("Error 12130: ENTWICKLERTEST")) {
+		t.Error("expected not retryable")
+	}
+}
+
+// TestBeginTx tests that write access is refused in read-only transactions.
+func TestBeginTx(t *testing.T) {
+
+	creator := "TestBeginTx"
+
+	type beginTxTest struct {
+		opts *sql.TxOptions
+		//
+		ok bool
+	}
+	beginTxTests := []beginTxTest{
+		{nil, true},
+		{&SerializableWrite, true},
+		{&ReadOnlySnapshot, false}, // write access refused
+	}
+
+	for i, v := range beginTxTests {
+		tx, err := BeginTx(context.Background(), v.opts) // begin transaction
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Fatal(err)
+		}
+
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+		_, err = tab.ByTs(Now, tx) // read access is always OK
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		}
+
+		_, err = tab.NewID(creator, tx) // <------- ACTION
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case !v.ok && !errors.Is(err, ErrInvalid):
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ErrInvalid, got:", err)
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+
+		err = Commit(tx.Tx) // end transaction
+		if err != nil {
+			err = translate(err, lang) // ******** l10n ********
+			t.Error(err)
+		}
+	}
+}
+
+// TestRunInTxReadOnly tests that write access is refused
+// in read-only transactions of RunInTx.
+func TestRunInTxReadOnly(t *testing.T) {
+
+	creator := "TestRunInTxReadOnly"
+
+	fn := func(tx *Tx) error {
+		if !tx.ReadOnly() {
+			t.Error("expected read-only transaction")
+		}
+		tab := Table{Name: tetab}
+		_, err := tab.NewID(creator, tx)
+		return err
+	}
+
+	opts := &RetryOpts{TxOpts: &ReadOnlySnapshot}
+	_, err := RunInTx(context.Background(), nil, fn, opts) // <------- ACTION
+	if err == nil {
+		t.Fatal("expected error, got ok")
+	}
+	if !errors.Is(err, ErrInvalid) {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal("expected ErrInvalid, got:", err)
+	}
+	err = translate(err, lang) // ******** l10n ********
+	t.Log("OK, error expected:", err)
+}
+
+// TestWithContext tests read and write access with a Querier bound to a context.
+func TestWithContext(t *testing.T) {
+
+	creator := "TestWithContext"
+
+	tx, err := Begin() // begin transaction
+	if err != nil {
+		err = translate(err, lang) // ******** l10n ********
+		t.Fatal(err)
+	}
+	defer Rollback(tx) // leave no test records
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	type withContextTest struct {
+		q     Querier
+		write bool
+		//
+		ok bool
+	}
+	withContextTests := []withContextTest{
+		{WithContext(context.Background(), nil), false, true},
+		{WithContext(context.Background(), tx), false, true},
+		{WithContext(context.Background(), tx), true, true},
+		{WithContext(canceled, nil), false, false},            // context canceled
+		{WithContext(canceled, tx), true, false},              // context canceled
+		{WithContext(context.Background(), nil), true, false}, // no transaction
+		{WithContext(context.Background(), GDb), true, false}, // no transaction
+	}
+
+	for i, v := range withContextTests {
+		tab := Table{Name: tetab, Atts: teAtts, Scan: teScan}
+
+		if v.write {
+			_, err = tab.NewID(creator, v.q) // <------- ACTION
+		} else {
+			_, err = tab.ByTs(Now, v.q) // <------- ACTION
+		}
+		switch {
+		case !v.ok && err == nil:
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected error, got ok")
+		case v.ok && err != nil:
+			err = translate(err, lang) // ******** l10n ********
+			t.Error("#"+fmt.Sprintf("%d", i+1), "expected ok, got error:", err)
+		case err != nil: // expected error
+			err = translate(err, lang) // ******** l10n ********
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK, error expected:", err)
+		default:
+			t.Log("#"+fmt.Sprintf("%d", i+1), "OK")
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"database/sql"
 	"fmt"
+	"reflect"
 
 	. "github.com/hwheinzen/stringl10n/mistake"
 )
@@ -40,13 +41,23 @@ func handleTs(ts string) (string, error) {
 					{"Name", out},
 				},
 			}
-			return "", fmt.Errorf(fnc+":%w", err)
+			return "", fmt.Errorf(fnc+":%w", MarkErr(ErrInvalid, err))
 		}
 	}
 	return out, nil
 }
 
-func writePrecs(ts, creator string, tx *sql.Tx) error {
+// equalIdv reports whether a and b contain the same individual attributes.
+// Unlike == it compares the values of pointer fields (nullable attributes)
+// and does not panic on slice fields.
+func (t *Table) equalIdv(a, b interface{}) bool {
+	if t.Vals != nil {
+		return reflect.DeepEqual(t.Vals(a), t.Vals(b))
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func writePrecs(ts, creator string, q Querier) error {
 	fnc := "writePrecs"
 
 	var err error
@@ -75,24 +86,28 @@ func writePrecs(ts, creator string, tx *sql.Tx) error {
 		}
 		return fmt.Errorf(fnc+":%w", err)
 	}
-	if tx == nil { // tx must be provided
+	if noTx(q) { // transaction must be provided
 		err = Err{Fix: "LIVEDB:write access needs transaction object"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err = readOnlyPrecs(q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 
 	return nil
 }
 
 // Table.NewID a new row of the ID-table and returns the ID.
-func (t *Table) NewID(creator string, tx *sql.Tx) (id int, err error) {
+func (t *Table) NewID(creator string, q Querier) (id int, err error) {
 	fnc := "Table.NewID"
 
-	err = t.newIDPrecs(creator, tx) // preconditions
+	err = t.newIDPrecs(creator, q) // preconditions
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
-	id, err = t.newID(creator, tx)
+	id, err = t.newID(creator, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -100,13 +115,17 @@ func (t *Table) NewID(creator string, tx *sql.Tx) (id int, err error) {
 	return id, nil
 }
 
-func (t *Table) newIDPrecs(creator string, tx *sql.Tx) error {
+func (t *Table) newIDPrecs(creator string, q Querier) error {
 	fnc := "Table.newIDPrecs"
 
-	if tx == nil {
+	if noTx(q) { // transaction must be provided
 		err := Err{Fix: "LIVEDB:write access needs transaction object"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err := readOnlyPrecs(q)
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 	if creator == "" {
 		err := Err{
 			Fix: "LIVEDB:{{.Name}} missing for {{.Table}}",
@@ -124,11 +143,15 @@ func (t *Table) newIDPrecs(creator string, tx *sql.Tx) error {
 		err := Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err = t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 
 	return nil
 }
 
-func (t *Table) newID(creator string, tx *sql.Tx) (int, error) {
+func (t *Table) newID(creator string, q Querier) (int, error) {
 	fnc := "Table.newID"
 
 	var buf1, buf2 bytes.Buffer
@@ -138,7 +161,7 @@ func (t *Table) newID(creator string, tx *sql.Tx) (int, error) {
 	sqlargs := []interface{}{}
 	num := int(0)
 
-	put1("insert into " + t.Name + "id (")
+	put1("insert into " + quote(t.Name+"id") + " (")
 	put2(") values (")
 
 	put1("created,")
@@ -157,11 +180,7 @@ func (t *Table) newID(creator string, tx *sql.Tx) (int, error) {
 	var res sql.Result
 	var err error
 
-	if tx != nil {
-		res, err = tx.Exec(s, sqlargs...)
-	} else {
-		res, err = GDb.Exec(s, sqlargs...)
-	}
+	res, err = dbExec(q, s, sqlargs...)
 	if err != nil {
 		e := Err{
 			Fix: "LIVEDB:error inserting by:{{.Query}}",
@@ -177,11 +196,7 @@ func (t *Table) newID(creator string, tx *sql.Tx) (int, error) {
 
 	var n int
 
-	if tx != nil {
-		n, err = t.insertedID(tx, res)
-	} else {
-		n, err = t.insertedID(nil, res)
-	}
+	n, err = t.insertedID(q, res)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -191,7 +206,7 @@ func (t *Table) newID(creator string, tx *sql.Tx) (int, error) {
 	return n, nil
 }
 
-func (t *Table) useID(creator string, tx *sql.Tx) error {
+func (t *Table) useID(creator string, q Querier) error {
 	fnc := "Table.useID"
 
 	sqlargs := []interface{}{}
@@ -200,7 +215,7 @@ func (t *Table) useID(creator string, tx *sql.Tx) error {
 	var buf bytes.Buffer
 	var put = buf.WriteString // write method
 
-	put("update " + t.Name + "id set ")
+	put("update " + quote(t.Name+"id") + " set ")
 
 	num++
 	put("usedby=" + FormatAtt(num))
@@ -224,7 +239,7 @@ func (t *Table) useID(creator string, tx *sql.Tx) error {
 	var res sql.Result
 	var err error
 
-	res, err = tx.Exec(s, sqlargs...)
+	res, err = dbExec(q, s, sqlargs...)
 
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing ID-table update"}
@@ -246,15 +261,15 @@ func (t *Table) useID(creator string, tx *sql.Tx) error {
 
 // Start creates a new object, inserts its first record and returns
 // the primary key of this record.
-func (t *Table) Start(id int, ts, creator string, tx *sql.Tx) (key int, err error) {
+func (t *Table) Start(id int, ts, creator string, q Querier) (key int, err error) {
 	fnc := "Table.Start"
 
-	err = t.startPrecs(id, ts, creator, tx) // preconditions
+	err = t.startPrecs(id, ts, creator, q) // preconditions
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
-	key, err = t.start(id, ts, creator, tx)
+	key, err = t.start(id, ts, creator, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -262,10 +277,10 @@ func (t *Table) Start(id int, ts, creator string, tx *sql.Tx) (key int, err erro
 	return key, nil
 }
 
-func (t *Table) startPrecs(id int, ts, creator string, tx *sql.Tx) error {
+func (t *Table) startPrecs(id int, ts, creator string, q Querier) error {
 	fnc := "Table.startPrecs"
 
-	err := writePrecs(ts, creator, tx)
+	err := writePrecs(ts, creator, q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -287,6 +302,10 @@ func (t *Table) startPrecs(id int, ts, creator string, tx *sql.Tx) error {
 		err = Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err = t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 	if t.New.Idv == nil { // t.New.Idv must be provided
 		err = Err{
 			Fix: "LIVEDB:{{.Name}} missing",
@@ -327,7 +346,7 @@ func (t *Table) startPrecs(id int, ts, creator string, tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) start(id int, ts, creator string, tx *sql.Tx) (int, error) {
+func (t *Table) start(id int, ts, creator string, q Querier) (int, error) {
 	fnc := "Table.start"
 
 	var err error
@@ -345,12 +364,12 @@ func (t *Table) start(id int, ts, creator string, tx *sql.Tx) (int, error) {
 		CreatedBy: creator,
 	}
 
-	key, err := t.ins(tx) // <-- ACTION INSERT
+	key, err := t.ins(q) // <-- ACTION INSERT
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
-	err = t.useID(creator, tx) // mark as used
+	err = t.useID(creator, q) // mark as used
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -359,15 +378,15 @@ func (t *Table) start(id int, ts, creator string, tx *sql.Tx) (int, error) {
 }
 
 // Terminate terminates a given record with Until = ts.
-func (t *Table) Terminate(ts, terminator string, tx *sql.Tx) (key int, err error) {
+func (t *Table) Terminate(ts, terminator string, q Querier) (key int, err error) {
 	fnc := "Table.Terminate"
 
-	err = t.terminatePrecs(ts, terminator, tx) // preconditions
+	err = t.terminatePrecs(ts, terminator, q) // preconditions
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
-	key, err = t.terminate(ts, terminator, tx)
+	key, err = t.terminate(ts, terminator, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -375,10 +394,10 @@ func (t *Table) Terminate(ts, terminator string, tx *sql.Tx) (key int, err error
 	return key, nil
 }
 
-func (t *Table) terminatePrecs(ts, terminator string, tx *sql.Tx) error {
+func (t *Table) terminatePrecs(ts, terminator string, q Querier) error {
 	fnc := "Table.terminatePrecs"
 
-	err := writePrecs(ts, terminator, tx)
+	err := writePrecs(ts, terminator, q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -387,6 +406,10 @@ func (t *Table) terminatePrecs(ts, terminator string, tx *sql.Tx) error {
 		err = Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err = t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 	if t.Old.Std.Pkey == 0 { // t.Old.Std must be provided
 		err = Err{
 			Fix: "LIVEDB:{{.Name}} missing",
@@ -439,7 +462,7 @@ func (t *Table) terminatePrecs(ts, terminator string, tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) terminate(ts, terminator string, tx *sql.Tx) (int, error) {
+func (t *Table) terminate(ts, terminator string, q Querier) (int, error) {
 	fnc := "Table.terminate"
 
 	var key = t.Old.Std.Pkey
@@ -454,26 +477,26 @@ func (t *Table) terminate(ts, terminator string, tx *sql.Tx) (int, error) {
 	}
 	if ts < t.Old.Std.Begin {
 		err = Err{Fix: "LIVEDB:not allowed"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
-	sames, err := t.byKey(t.Old.Std.Pkey, tx)
+	sames, err := t.byKey(t.Old.Std.Pkey, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 	same := sames[0]
 	if same.Std.Pkey == 0 {
 		err = Err{Fix: "LIVEDB:competetively deleted"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
-	if t.Old.Idv != same.Idv || t.Old.Std != same.Std {
+	if !t.equalIdv(t.Old.Idv, same.Idv) || t.Old.Std != same.Std {
 		err = Err{Fix: "LIVEDB:competetively changed"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
 	if ts == t.Old.Std.Begin {
 		t.New.Std = t.Old.Std
-		err = t.del(tx) // <-- ACTION: delete
+		err = t.del(q) // <-- ACTION: delete
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
@@ -482,14 +505,14 @@ func (t *Table) terminate(ts, terminator string, tx *sql.Tx) (int, error) {
 		t.New.Std = t.Old.Std
 		t.New.Std.Until = ts
 		t.New.Std.EndedBy = terminator
-		err = t.until(tx) // <-- ACTION: update Until
+		err = t.until(q) // <-- ACTION: update Until
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
 	}
 
 	if t.Old.Std.Until != "" {
-		nexts, err := t.byIDBegin(t.Old.Std.ID, t.Old.Std.Until, tx) // read follower
+		nexts, err := t.byIDBegin(t.Old.Std.ID, t.Old.Std.Until, q) // read follower
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
@@ -499,12 +522,12 @@ func (t *Table) terminate(ts, terminator string, tx *sql.Tx) (int, error) {
 		}
 		for next.Std.Pkey != 0 {
 			t.New.Std = next.Std
-			err = t.del(tx) // <-- ACTION delete all followers
+			err = t.del(q) // <-- ACTION delete all followers
 			if err != nil {
 				return 0, fmt.Errorf(fnc+":%w", err)
 			}
 			if next.Std.Until != "" {
-				nexts, err = t.byIDBegin(next.Std.ID, next.Std.Until, tx) // next
+				nexts, err = t.byIDBegin(next.Std.ID, next.Std.Until, q) // next
 				if err != nil {
 					return 0, fmt.Errorf(fnc+":%w", err)
 				}
@@ -524,19 +547,19 @@ func (t *Table) terminate(ts, terminator string, tx *sql.Tx) (int, error) {
 // It usually creates a new record and updates the Until attribute of a predecessor.
 // It may only update the given (future) record.
 // It evantually creates a new record with a new ID.
-func (t *Table) Change(ts, creator string, tx *sql.Tx, opts ...func(*Table)) (key int, err error) {
+func (t *Table) Change(ts, creator string, q Querier, opts ...func(*Table)) (key int, err error) {
 	fnc := "Table.Change"
 
 	for _, opt := range opts { // non-default options
 		opt(t)
 	}
 
-	err = t.changePrecs(ts, creator, tx) // preconditions
+	err = t.changePrecs(ts, creator, q) // preconditions
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
-	key, err = t.change(ts, creator, tx)
+	key, err = t.change(ts, creator, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -544,10 +567,10 @@ func (t *Table) Change(ts, creator string, tx *sql.Tx, opts ...func(*Table)) (ke
 	return key, nil
 }
 
-func (t *Table) changePrecs(ts, creator string, tx *sql.Tx) error {
+func (t *Table) changePrecs(ts, creator string, q Querier) error {
 	fnc := "Table.changePrecs"
 
-	err := writePrecs(ts, creator, tx)
+	err := writePrecs(ts, creator, q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -556,6 +579,10 @@ func (t *Table) changePrecs(ts, creator string, tx *sql.Tx) error {
 		err = Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err = t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 	if t.Old.Std.Pkey == 0 { // t.Old.Std must be provided
 		err = Err{
 			Fix: "LIVEDB:{{.Name}} missing",
@@ -632,7 +659,7 @@ func (t *Table) changePrecs(ts, creator string, tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) change(ts, creator string, tx *sql.Tx) (int, error) {
+func (t *Table) change(ts, creator string, q Querier) (int, error) {
 	fnc := "Table.change"
 
 	var err error
@@ -642,29 +669,29 @@ func (t *Table) change(ts, creator string, tx *sql.Tx) (int, error) {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 	
-	if t.New.Idv == t.Old.Idv {
+	if t.equalIdv(t.New.Idv, t.Old.Idv) {
 		return t.Old.Std.Pkey, nil // NOTHING CHANGED
 	}
 
-	sames, err := t.byKey(t.Old.Std.Pkey, tx)
+	sames, err := t.byKey(t.Old.Std.Pkey, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 	same := sames[0]
 	if same.Std.Pkey == 0 {
 		err = Err{Fix: "LIVEDB:competetively deleted"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
-	if t.Old.Idv != same.Idv || t.Old.Std != same.Std {
+	if !t.equalIdv(t.Old.Idv, same.Idv) || t.Old.Std != same.Std {
 		err = Err{Fix: "LIVEDB:competetively changed"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
 	if ts == t.Old.Std.Begin {
 
 		t.New.Std = t.Old.Std
 		t.New.Std.CreatedBy = creator
-		err = t.upd(tx) // <-- ACTION UPDATE
+		err = t.upd(q) // <-- ACTION UPDATE
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
@@ -677,7 +704,7 @@ func (t *Table) change(ts, creator string, tx *sql.Tx) (int, error) {
 			Begin:     ts,
 			CreatedBy: creator,
 		}
-		key, err := t.ins(tx) // <-- ACTION INSERT
+		key, err := t.ins(q) // <-- ACTION INSERT
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
@@ -685,7 +712,7 @@ func (t *Table) change(ts, creator string, tx *sql.Tx) (int, error) {
 		t.New.Std = t.Old.Std
 		t.New.Std.Until = ts
 		t.New.Std.EndedBy = creator
-		err = t.until(tx) //  <-- ACTION UPDATE until
+		err = t.until(q) //  <-- ACTION UPDATE until
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
@@ -695,15 +722,15 @@ func (t *Table) change(ts, creator string, tx *sql.Tx) (int, error) {
 }
 
 // MoveBegin begins a given record with Begin = ts.
-func (t *Table) MoveBegin(ts, creator string, tx *sql.Tx) (key int, err error) {
+func (t *Table) MoveBegin(ts, creator string, q Querier) (key int, err error) {
 	fnc := "Table.MoveBegin"
 
-	err = t.moveBeginPrecs(ts, creator, tx) // preconditions
+	err = t.moveBeginPrecs(ts, creator, q) // preconditions
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
-	key, err = t.moveBegin(ts, creator, tx)
+	key, err = t.moveBegin(ts, creator, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -711,10 +738,10 @@ func (t *Table) MoveBegin(ts, creator string, tx *sql.Tx) (key int, err error) {
 	return key, nil
 }
 
-func (t *Table) moveBeginPrecs(ts, creator string, tx *sql.Tx) error {
+func (t *Table) moveBeginPrecs(ts, creator string, q Querier) error {
 	fnc := "Table.moveBeginPrecs"
 
-	err := writePrecs(ts, creator, tx)
+	err := writePrecs(ts, creator, q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -723,6 +750,10 @@ func (t *Table) moveBeginPrecs(ts, creator string, tx *sql.Tx) error {
 		err = Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err = t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 	if t.Old.Std.Pkey == 0 { // t.Old.Std must be provided
 		err = Err{
 			Fix: "LIVEDB:{{.Name}} missing",
@@ -775,7 +806,7 @@ func (t *Table) moveBeginPrecs(ts, creator string, tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) moveBegin(ts, creator string, tx *sql.Tx) (int, error) {
+func (t *Table) moveBegin(ts, creator string, q Querier) (int, error) {
 	fnc := "Table.moveBegin"
 
 	var key = t.Old.Std.Pkey
@@ -788,28 +819,28 @@ func (t *Table) moveBegin(ts, creator string, tx *sql.Tx) (int, error) {
 	if ts == t.Old.Std.Begin {
 		return t.Old.Std.Pkey, nil // NO CHANGE
 	}
-	if ts > t.Old.Std.Until {
+	if t.Old.Std.Until != "" && ts > t.Old.Std.Until { // "" is open end
 		err = Err{Fix: "LIVEDB:not allowed"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
-	sames, err := t.byKey(t.Old.Std.Pkey, tx)
+	sames, err := t.byKey(t.Old.Std.Pkey, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 	same := sames[0]
 	if same.Std.Pkey == 0 {
 		err = Err{Fix: "LIVEDB:competetively deleted"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
-	if t.Old.Idv != same.Idv || t.Old.Std != same.Std {
+	if !t.equalIdv(t.Old.Idv, same.Idv) || t.Old.Std != same.Std {
 		err = Err{Fix: "LIVEDB:competetively changed"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
 	if ts == t.Old.Std.Until {
 		t.New.Std = t.Old.Std
-		err = t.del(tx) // <-- ACTION: delete
+		err = t.del(q) // <-- ACTION: delete
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
@@ -818,13 +849,13 @@ func (t *Table) moveBegin(ts, creator string, tx *sql.Tx) (int, error) {
 		t.New.Std = t.Old.Std
 		t.New.Std.Begin = ts
 		t.New.Std.EndedBy = creator
-		err = t.begin(tx) // <-- ACTION: update Begin
+		err = t.begin(q) // <-- ACTION: update Begin
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
 	}
 
-	nexts, err := t.byIDUntil(t.Old.Std.ID, t.Old.Std.Begin, tx) // read preceder
+	nexts, err := t.byIDUntil(t.Old.Std.ID, t.Old.Std.Begin, q) // read preceder
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -835,11 +866,11 @@ func (t *Table) moveBegin(ts, creator string, tx *sql.Tx) (int, error) {
 	if ts < t.Old.Std.Begin {
 		for len(nexts) != 0 && next.Std.Begin > ts {
 			t.New.Std = next.Std
-			err = t.del(tx) // <-- ACTION: DELETE shadowed preceder
+			err = t.del(q) // <-- ACTION: DELETE shadowed preceder
 			if err != nil {
 				return 0, fmt.Errorf(fnc+":%w", err)
 			}
-			nexts, err = t.byIDUntil(next.Std.ID, next.Std.Begin, tx) // next
+			nexts, err = t.byIDUntil(next.Std.ID, next.Std.Begin, q) // next
 			if err != nil {
 				return 0, fmt.Errorf(fnc+":%w", err)
 			}
@@ -851,7 +882,7 @@ func (t *Table) moveBegin(ts, creator string, tx *sql.Tx) (int, error) {
 	t.New.Std = next.Std
 	t.New.Std.Until = ts
 	t.New.Std.CreatedBy = creator
-	err = t.until(tx) //  <-- ACTION: UPDATE preceder's until
+	err = t.until(q) //  <-- ACTION: UPDATE preceder's until
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -860,15 +891,15 @@ func (t *Table) moveBegin(ts, creator string, tx *sql.Tx) (int, error) {
 }
 
 // MoveUntil ends a given record with Until = ts.
-func (t *Table) MoveUntil(ts, creator string, tx *sql.Tx) (key int, err error) {
+func (t *Table) MoveUntil(ts, creator string, q Querier) (key int, err error) {
 	fnc := "Table.MoveUntil"
 
-	err = t.moveUntilPrecs(ts, creator, tx) // preconditions
+	err = t.moveUntilPrecs(ts, creator, q) // preconditions
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 
-	key, err = t.moveUntil(ts, creator, tx)
+	key, err = t.moveUntil(ts, creator, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -876,10 +907,10 @@ func (t *Table) MoveUntil(ts, creator string, tx *sql.Tx) (key int, err error) {
 	return key, nil
 }
 
-func (t *Table) moveUntilPrecs(ts, creator string, tx *sql.Tx) error {
+func (t *Table) moveUntilPrecs(ts, creator string, q Querier) error {
 	fnc := "Table.moveUntilPrecs"
 
-	err := writePrecs(ts, creator, tx)
+	err := writePrecs(ts, creator, q)
 	if err != nil {
 		return fmt.Errorf(fnc+":%w", err)
 	}
@@ -888,6 +919,10 @@ func (t *Table) moveUntilPrecs(ts, creator string, tx *sql.Tx) error {
 		err = Err{Fix: "LIVEDB:table name missing"}
 		return fmt.Errorf(fnc+":%w", err)
 	}
+	err = t.namesPrecs() // no SQL injection
+	if err != nil {
+		return fmt.Errorf(fnc+":%w", err)
+	}
 	if t.Old.Std.Pkey == 0 { // t.Old.Std must be provided
 		err = Err{
 			Fix: "LIVEDB:{{.Name}} missing",
@@ -940,7 +975,7 @@ func (t *Table) moveUntilPrecs(ts, creator string, tx *sql.Tx) error {
 	return nil
 }
 
-func (t *Table) moveUntil(ts, creator string, tx *sql.Tx) (int, error) {
+func (t *Table) moveUntil(ts, creator string, q Querier) (int, error) {
 	fnc := "Table.moveUntil"
 
 	var key = t.Old.Std.Pkey
@@ -955,26 +990,26 @@ func (t *Table) moveUntil(ts, creator string, tx *sql.Tx) (int, error) {
 	}
 	if ts < t.Old.Std.Begin {
 		err = Err{Fix: "LIVEDB:not allowed"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
-	sames, err := t.byKey(t.Old.Std.Pkey, tx)
+	sames, err := t.byKey(t.Old.Std.Pkey, q)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
 	same := sames[0]
 	if same.Std.Pkey == 0 {
 		err = Err{Fix: "LIVEDB:competetively deleted"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
-	if t.Old.Idv != same.Idv || t.Old.Std != same.Std {
+	if !t.equalIdv(t.Old.Idv, same.Idv) || t.Old.Std != same.Std {
 		err = Err{Fix: "LIVEDB:competetively changed"}
-		return 0, fmt.Errorf(fnc+":%w", err)
+		return 0, fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
 	if ts == t.Old.Std.Begin {
 		t.New.Std = t.Old.Std
-		err = t.del(tx) // <-- ACTION: delete
+		err = t.del(q) // <-- ACTION: delete
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
@@ -983,14 +1018,14 @@ func (t *Table) moveUntil(ts, creator string, tx *sql.Tx) (int, error) {
 		t.New.Std = t.Old.Std
 		t.New.Std.Until = ts
 		t.New.Std.EndedBy = creator
-		err = t.until(tx) // <-- ACTION: update Until
+		err = t.until(q) // <-- ACTION: update Until
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
 	}
 
 	if t.Old.Std.Until != "" {
-		nexts, err := t.byIDBegin(t.Old.Std.ID, t.Old.Std.Until, tx) // read follower
+		nexts, err := t.byIDBegin(t.Old.Std.ID, t.Old.Std.Until, q) // read follower
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
@@ -1001,12 +1036,12 @@ func (t *Table) moveUntil(ts, creator string, tx *sql.Tx) (int, error) {
 		if ts > t.Old.Std.Until {
 			for len(nexts) != 0 && next.Std.Until != "" && next.Std.Until < ts {
 				t.New.Std = next.Std
-				err = t.del(tx) // <-- ACTION: delete shadowed followers
+				err = t.del(q) // <-- ACTION: delete shadowed followers
 				if err != nil {
 					return 0, fmt.Errorf(fnc+":%w", err)
 				}
 				if next.Std.Until != "" {
-					nexts, err = t.byIDBegin(next.Std.ID, next.Std.Until, tx) // next
+					nexts, err = t.byIDBegin(next.Std.ID, next.Std.Until, q) // next
 					if err != nil {
 						return 0, fmt.Errorf(fnc+":%w", err)
 					}
@@ -1019,7 +1054,7 @@ func (t *Table) moveUntil(ts, creator string, tx *sql.Tx) (int, error) {
 		t.New.Std = next.Std
 		t.New.Std.Begin = ts
 		t.New.Std.CreatedBy = creator
-		err = t.begin(tx) // <-- ACTION: update follower's begin
+		err = t.begin(q) // <-- ACTION: update follower's begin
 		if err != nil {
 			return 0, fmt.Errorf(fnc+":%w", err)
 		}
@@ -1028,7 +1063,7 @@ func (t *Table) moveUntil(ts, creator string, tx *sql.Tx) (int, error) {
 	return key, nil
 }
 
-func (t *Table) ins(tx *sql.Tx) (int, error) {
+func (t *Table) ins(q Querier) (int, error) {
 	fnc := "Table.ins"
 
 	vals := t.Vals(t.New.Idv)
@@ -1040,7 +1075,7 @@ func (t *Table) ins(tx *sql.Tx) (int, error) {
 	sqlargs := []interface{}{}
 	num := int(0)
 
-	put1("insert into " + t.Name + " (")
+	put1("insert into " + quote(t.Name) + " (")
 	put2(") values (")
 
 	// standard atts
@@ -1092,9 +1127,9 @@ func (t *Table) ins(tx *sql.Tx) (int, error) {
 
 	// table specific atts
 	for i, att := range t.Atts {
-		if vals[i] != "" { // otherwise NULL
+		if vals[i] != nil { // otherwise NULL
 			num++
-			put1(att + ",")
+			put1(quote(att) + ",")
 			put2(FormatAtt(num) + ",")
 			sqlargs = append(sqlargs, vals[i])
 		}
@@ -1106,13 +1141,13 @@ func (t *Table) ins(tx *sql.Tx) (int, error) {
 	Log("s:", s)
 	Log("sqlargs:", sqlargs)
 
-	r, err := tx.Exec(s, sqlargs...)
+	r, err := dbExec(q, s, sqlargs...)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing insert"}
 		return 0, fmt.Errorf(fnc+":%w:"+err.Error(), e)
 	}
 
-	key, err := t.insertedKey(tx, r)
+	key, err := t.insertedKey(q, r)
 	if err != nil {
 		return 0, fmt.Errorf(fnc+":%w", err)
 	}
@@ -1122,7 +1157,7 @@ func (t *Table) ins(tx *sql.Tx) (int, error) {
 	return key, nil
 }
 
-func (t *Table) upd(tx *sql.Tx) error {
+func (t *Table) upd(q Querier) error {
 	fnc := "Table.upd"
 
 	vals := t.Vals(t.New.Idv)
@@ -1133,7 +1168,7 @@ func (t *Table) upd(tx *sql.Tx) error {
 	var buf bytes.Buffer
 	var put = buf.WriteString // write method
 
-	put("update " + t.Name + " set ")
+	put("update " + quote(t.Name) + " set ")
 
 	put("created=" + FormatNow())
 	if t.New.Std.CreatedBy != "" {
@@ -1144,12 +1179,12 @@ func (t *Table) upd(tx *sql.Tx) error {
 
 	// table specific atts
 	for i, att := range t.Atts {
-		if vals[i] != "" {
+		if vals[i] != nil {
 			num++
-			put("," + att + "=" + FormatAtt(num))
+			put("," + quote(att) + "=" + FormatAtt(num))
 			sqlargs = append(sqlargs, vals[i])
 		} else {
-			put("," + att + "=" + FormatNull())
+			put("," + quote(att) + "=" + FormatNull())
 		}
 	}
 
@@ -1163,7 +1198,7 @@ func (t *Table) upd(tx *sql.Tx) error {
 	Log("sqlargs:", sqlargs)
 
 	var r sql.Result
-	r, err := tx.Exec(s, sqlargs...)
+	r, err := dbExec(q, s, sqlargs...)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing update"}
 		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
@@ -1176,13 +1211,13 @@ func (t *Table) upd(tx *sql.Tx) error {
 	}
 	if n == 0 {
 		err = Err{Fix: "LIVEDB:nothing updated"}
-		return fmt.Errorf(fnc+":%w", err)
+		return fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
 	return nil
 }
 
-func (t *Table) del(tx *sql.Tx) error {
+func (t *Table) del(q Querier) error {
 	fnc := "Table.del"
 
 	var buf bytes.Buffer
@@ -1191,7 +1226,7 @@ func (t *Table) del(tx *sql.Tx) error {
 	sqlargs := []interface{}{}
 	num := int(0)
 
-	put("delete from " + t.Name)
+	put("delete from " + quote(t.Name))
 
 	num++
 	put(" where pkey=" + FormatAtt(num) + ";")
@@ -1203,7 +1238,7 @@ func (t *Table) del(tx *sql.Tx) error {
 	Log("sqlargs:", sqlargs)
 
 	var r sql.Result
-	r, err := tx.Exec(s, sqlargs...)
+	r, err := dbExec(q, s, sqlargs...)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing delete"}
 		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
@@ -1216,13 +1251,13 @@ func (t *Table) del(tx *sql.Tx) error {
 	}
 	if n == 0 {
 		err = Err{Fix: "LIVEDB:nothing deleted"}
-		return fmt.Errorf(fnc+":%w", err)
+		return fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
 	return nil
 }
 
-func (t *Table) begin(tx *sql.Tx) error {
+func (t *Table) begin(q Querier) error {
 	fnc := "Table.begin"
 
 	var buf bytes.Buffer
@@ -1231,7 +1266,7 @@ func (t *Table) begin(tx *sql.Tx) error {
 	sqlargs := []interface{}{}
 	num := int(0)
 
-	put("update " + t.Name + " set ")
+	put("update " + quote(t.Name) + " set ")
 
 	if t.New.Std.Begin == Now {
 		put("begin=" + FormatNow() + ",")
@@ -1257,7 +1292,7 @@ func (t *Table) begin(tx *sql.Tx) error {
 	Log("sqlargs:", sqlargs)
 
 	var r sql.Result
-	r, err := tx.Exec(s, sqlargs...)
+	r, err := dbExec(q, s, sqlargs...)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing update"}
 		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
@@ -1270,13 +1305,13 @@ func (t *Table) begin(tx *sql.Tx) error {
 	}
 	if n == 0 {
 		err = Err{Fix: "LIVEDB:nothing updated"}
-		return fmt.Errorf(fnc+":%w", err)
+		return fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
 	return nil
 }
 
-func (t *Table) until(tx *sql.Tx) error {
+func (t *Table) until(q Querier) error {
 	fnc := "Table.until"
 
 	var buf bytes.Buffer
@@ -1285,7 +1320,7 @@ func (t *Table) until(tx *sql.Tx) error {
 	sqlargs := []interface{}{}
 	num := int(0)
 
-	put("update " + t.Name + " set ")
+	put("update " + quote(t.Name) + " set ")
 
 	if t.New.Std.Until == Now {
 		put("until=" + FormatNow() + ",")
@@ -1311,7 +1346,7 @@ func (t *Table) until(tx *sql.Tx) error {
 	Log("sqlargs:", sqlargs)
 
 	var r sql.Result
-	r, err := tx.Exec(s, sqlargs...)
+	r, err := dbExec(q, s, sqlargs...)
 	if err != nil {
 		e := Err{Fix: "LIVEDB:error executing update"}
 		return fmt.Errorf(fnc+":%w:"+err.Error(), e)
@@ -1324,7 +1359,7 @@ func (t *Table) until(tx *sql.Tx) error {
 	}
 	if n == 0 {
 		err = Err{Fix: "LIVEDB:nothing updated"}
-		return fmt.Errorf(fnc+":%w", err)
+		return fmt.Errorf(fnc+":%w", MarkErr(ErrConflict, err))
 	}
 
 	return nil